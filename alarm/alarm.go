@@ -0,0 +1,244 @@
+// Package alarm evaluates per-zone temperature and humidity thresholds
+// continuously and delivers notifications when they are crossed, so that
+// alerts keep working independently of any scripting host. The tadod command
+// runs a Monitor as a daemon.
+package alarm
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/idriesalbender/go-tado/notify"
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// Kind represents the kind of an alarm.
+type Kind string
+
+const (
+	TemperatureLow  Kind = "TEMPERATURE_LOW"
+	TemperatureHigh Kind = "TEMPERATURE_HIGH"
+	HumidityLow     Kind = "HUMIDITY_LOW"
+	HumidityHigh    Kind = "HUMIDITY_HIGH"
+)
+
+// Rule holds the thresholds of a zone. Nil thresholds are not evaluated.
+// Temperatures are in Unit, humidities in percent.
+type Rule struct {
+	ZoneID         tado.ZoneID `json:"zoneId"`
+	MinTemperature *float64    `json:"minTemperature,omitempty"`
+	MaxTemperature *float64    `json:"maxTemperature,omitempty"`
+	MinHumidity    *float64    `json:"minHumidity,omitempty"`
+	MaxHumidity    *float64    `json:"maxHumidity,omitempty"`
+
+	// Unit is the unit of the temperature thresholds. If empty, Evaluate
+	// takes them as Celsius and Monitor uses the preferred unit of the home,
+	// see tado.Client.PreferredUnit.
+	Unit tado.TemperatureUnit `json:"unit,omitempty"`
+}
+
+// Alarm is a threshold of a rule that is crossed. The Value and Threshold of
+// temperature alarms are in Unit.
+type Alarm struct {
+	ZoneID    tado.ZoneID
+	Kind      Kind
+	Value     float64
+	Threshold float64
+	Unit      tado.TemperatureUnit
+}
+
+// String returns a human-readable description of the alarm.
+func (a Alarm) String() string {
+	symbol := a.Unit.Symbol()
+
+	switch a.Kind {
+	case TemperatureLow:
+		return fmt.Sprintf("zone %d temperature %.1f%s is below %.1f%s", a.ZoneID, a.Value, symbol, a.Threshold, symbol)
+	case TemperatureHigh:
+		return fmt.Sprintf("zone %d temperature %.1f%s is above %.1f%s", a.ZoneID, a.Value, symbol, a.Threshold, symbol)
+	case HumidityLow:
+		return fmt.Sprintf("zone %d humidity %.0f%% is below %.0f%%", a.ZoneID, a.Value, a.Threshold)
+	case HumidityHigh:
+		return fmt.Sprintf("zone %d humidity %.0f%% is above %.0f%%", a.ZoneID, a.Value, a.Threshold)
+	default:
+		return fmt.Sprintf("zone %d %s", a.ZoneID, a.Kind)
+	}
+}
+
+// ClearedString returns a human-readable description of the alarm having
+// been cleared.
+func (a Alarm) ClearedString() string {
+	switch a.Kind {
+	case TemperatureLow:
+		return fmt.Sprintf("zone %d temperature is no longer below %.1f%s", a.ZoneID, a.Threshold, a.Unit.Symbol())
+	case TemperatureHigh:
+		return fmt.Sprintf("zone %d temperature is no longer above %.1f%s", a.ZoneID, a.Threshold, a.Unit.Symbol())
+	case HumidityLow:
+		return fmt.Sprintf("zone %d humidity is no longer below %.0f%%", a.ZoneID, a.Threshold)
+	case HumidityHigh:
+		return fmt.Sprintf("zone %d humidity is no longer above %.0f%%", a.ZoneID, a.Threshold)
+	default:
+		return fmt.Sprintf("zone %d %s cleared", a.ZoneID, a.Kind)
+	}
+}
+
+// Evaluate returns the alarms of rule for the given zone state.
+func Evaluate(rule Rule, state *tado.ZoneState) []Alarm {
+	var alarms []Alarm
+
+	unit := rule.Unit
+	if unit != tado.UnitFahrenheit {
+		unit = tado.UnitCelsius
+	}
+
+	check := func(kind Kind, value float64, threshold *float64, crossed func(v, t float64) bool) {
+		if threshold != nil && crossed(value, *threshold) {
+			alarms = append(alarms, Alarm{ZoneID: rule.ZoneID, Kind: kind, Value: value, Threshold: *threshold, Unit: unit})
+		}
+	}
+	below := func(v, t float64) bool { return v < t }
+	above := func(v, t float64) bool { return v > t }
+
+	if t := state.SensorDataPoints.InsideTemperature; t != nil {
+		check(TemperatureLow, t.In(unit), rule.MinTemperature, below)
+		check(TemperatureHigh, t.In(unit), rule.MaxTemperature, above)
+	}
+
+	if h := state.SensorDataPoints.Humidity; h != nil {
+		check(HumidityLow, h.Percentage, rule.MinHumidity, below)
+		check(HumidityHigh, h.Percentage, rule.MaxHumidity, above)
+	}
+
+	return alarms
+}
+
+// Monitor polls the zones of a home and notifies the sinks when an alarm is
+// raised or cleared. Alarms are only notified on transitions, not on every
+// poll. Interval defaults to tado.DefaultWatchInterval.
+type Monitor struct {
+	Client   *tado.Client
+	HomeID   tado.HomeID
+	Rules    []Rule
+	Interval time.Duration
+	Sinks    []notify.Notifier
+
+	// OnError is called with polling and delivery errors, if not nil.
+	OnError func(error)
+
+	active map[alarmKey]Alarm
+}
+
+// alarmKey identifies an active alarm.
+type alarmKey struct {
+	zoneID tado.ZoneID
+	kind   Kind
+}
+
+// Run polls and evaluates the rules at the configured interval until ctx is
+// done.
+func (m *Monitor) Run(ctx context.Context) error {
+	if m.active == nil {
+		m.active = map[alarmKey]Alarm{}
+	}
+
+	interval := m.Interval
+	if interval <= 0 {
+		interval = tado.DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		m.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll evaluates all rules once, fetching the states of all zones in a single
+// request.
+func (m *Monitor) poll(ctx context.Context) {
+	states, err := m.Client.Zone.States(ctx, m.HomeID)
+	if err != nil {
+		m.error(err)
+		return
+	}
+
+	unit, err := m.Client.PreferredUnit(ctx, m.HomeID)
+	if err != nil {
+		m.error(err)
+		return
+	}
+
+	// the alarms of all rules are collected before clearing, as several
+	// rules may apply to the same zone
+	var raised []Alarm
+	evaluated := map[tado.ZoneID]bool{}
+	for _, rule := range m.Rules {
+		state, ok := states[rule.ZoneID]
+		if !ok {
+			m.error(fmt.Errorf("zone %d: no state", rule.ZoneID))
+			continue
+		}
+
+		if rule.Unit == "" {
+			rule.Unit = unit
+		}
+
+		evaluated[rule.ZoneID] = true
+		raised = append(raised, Evaluate(rule, state)...)
+	}
+
+	current := map[alarmKey]bool{}
+	for _, a := range raised {
+		key := alarmKey{a.ZoneID, a.Kind}
+		current[key] = true
+
+		if _, ok := m.active[key]; !ok {
+			m.active[key] = a
+			m.notify(ctx, notify.Message{Title: "Tado alarm", Text: a.String()})
+		}
+	}
+
+	for _, key := range slices.SortedFunc(maps.Keys(m.active), compareAlarmKeys) {
+		if evaluated[key.zoneID] && !current[key] {
+			a := m.active[key]
+			delete(m.active, key)
+			m.notify(ctx, notify.Message{Title: "Tado alarm cleared", Text: a.ClearedString()})
+		}
+	}
+}
+
+// compareAlarmKeys orders alarm keys by zone and kind.
+func compareAlarmKeys(a, b alarmKey) int {
+	if c := cmp.Compare(a.zoneID, b.zoneID); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(a.kind, b.kind)
+}
+
+// notify delivers msg to all sinks.
+func (m *Monitor) notify(ctx context.Context, msg notify.Message) {
+	for _, sink := range m.Sinks {
+		if err := sink.Notify(ctx, msg); err != nil {
+			m.error(err)
+		}
+	}
+}
+
+// error reports err to OnError.
+func (m *Monitor) error(err error) {
+	if m.OnError != nil {
+		m.OnError(err)
+	}
+}
@@ -0,0 +1,200 @@
+package alarm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/idriesalbender/go-tado/notify"
+	"github.com/idriesalbender/go-tado/tado"
+	"github.com/idriesalbender/go-tado/tadotest"
+)
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+// zoneState returns a zone state with the given temperature in degrees
+// Celsius and humidity in percent.
+func zoneState(celsius, humidity float64) tado.ZoneState {
+	var state tado.ZoneState
+	state.SensorDataPoints.InsideTemperature = &tado.TemperatureDataPoint{Temperature: tado.Celsius(celsius)}
+	state.SensorDataPoints.Humidity = &tado.PercentageDataPoint{Percentage: humidity}
+	return state
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		state tado.ZoneState
+		want  []Alarm
+	}{
+		{
+			name:  "temperature below minimum",
+			rule:  Rule{ZoneID: 1, MinTemperature: ptr(18)},
+			state: zoneState(15, 50),
+			want:  []Alarm{{ZoneID: 1, Kind: TemperatureLow, Value: 15, Threshold: 18, Unit: tado.UnitCelsius}},
+		},
+		{
+			name:  "temperature within thresholds",
+			rule:  Rule{ZoneID: 1, MinTemperature: ptr(18), MaxTemperature: ptr(22)},
+			state: zoneState(18, 50),
+		},
+		{
+			name:  "humidity above maximum",
+			rule:  Rule{ZoneID: 1, MinHumidity: ptr(30), MaxHumidity: ptr(60)},
+			state: zoneState(20, 70),
+			want:  []Alarm{{ZoneID: 1, Kind: HumidityHigh, Value: 70, Threshold: 60, Unit: tado.UnitCelsius}},
+		},
+		{
+			name:  "without thresholds",
+			rule:  Rule{ZoneID: 1},
+			state: zoneState(-5, 100),
+		},
+		{
+			name:  "without sensor data",
+			rule:  Rule{ZoneID: 1, MinTemperature: ptr(18), MaxHumidity: ptr(60)},
+			state: tado.ZoneState{},
+		},
+		{
+			name:  "temperature below minimum in Fahrenheit",
+			rule:  Rule{ZoneID: 1, MinTemperature: ptr(64.4), Unit: tado.UnitFahrenheit},
+			state: zoneState(15, 50),
+			want:  []Alarm{{ZoneID: 1, Kind: TemperatureLow, Value: 59, Threshold: 64.4, Unit: tado.UnitFahrenheit}},
+		},
+		{
+			name:  "temperature above minimum in Fahrenheit",
+			rule:  Rule{ZoneID: 1, MinTemperature: ptr(60), Unit: tado.UnitFahrenheit},
+			state: zoneState(18, 50),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Evaluate(tt.rule, &tt.state); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMonitor_poll(t *testing.T) {
+	type step struct {
+		states map[tado.ZoneID]tado.ZoneState
+		want   []string
+	}
+
+	tests := []struct {
+		name  string
+		unit  tado.TemperatureUnit
+		rules []Rule
+		steps []step
+	}{
+		{
+			name:  "fire, stay quiet and clear",
+			rules: []Rule{{ZoneID: 1, MinTemperature: ptr(18)}},
+			steps: []step{
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(15, 50)},
+					want:   []string{"Tado alarm: zone 1 temperature 15.0°C is below 18.0°C"},
+				},
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(16, 50)},
+				},
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(19, 50)},
+					want:   []string{"Tado alarm cleared: zone 1 temperature is no longer below 18.0°C"},
+				},
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(19, 50)},
+				},
+			},
+		},
+		{
+			name: "clear across rules of a zone",
+			rules: []Rule{
+				{ZoneID: 1, MinTemperature: ptr(18)},
+				{ZoneID: 1, MaxHumidity: ptr(60)},
+				{ZoneID: 2, MaxTemperature: ptr(25)},
+			},
+			steps: []step{
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(15, 70), 2: zoneState(20, 50)},
+					want: []string{
+						"Tado alarm: zone 1 temperature 15.0°C is below 18.0°C",
+						"Tado alarm: zone 1 humidity 70% is above 60%",
+					},
+				},
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(19, 70), 2: zoneState(26, 50)},
+					want: []string{
+						"Tado alarm: zone 2 temperature 26.0°C is above 25.0°C",
+						"Tado alarm cleared: zone 1 temperature is no longer below 18.0°C",
+					},
+				},
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(19, 50), 2: zoneState(26, 50)},
+					want:   []string{"Tado alarm cleared: zone 1 humidity is no longer above 60%"},
+				},
+			},
+		},
+		{
+			name:  "thresholds in the unit of the home",
+			unit:  tado.UnitFahrenheit,
+			rules: []Rule{{ZoneID: 1, MinTemperature: ptr(64.4)}, {ZoneID: 2, MaxTemperature: ptr(20), Unit: tado.UnitCelsius}},
+			steps: []step{
+				{
+					states: map[tado.ZoneID]tado.ZoneState{1: zoneState(15, 50), 2: zoneState(21, 50)},
+					want: []string{
+						"Tado alarm: zone 1 temperature 59.0°F is below 64.4°F",
+						"Tado alarm: zone 2 temperature 21.0°C is above 20.0°C",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := tadotest.NewServer()
+			defer srv.Close()
+
+			srv.AddHome(tadotest.Home{
+				Home: tado.Home{ID: 1, Name: "Home", TemperatureUnit: tt.unit},
+				Zones: []tadotest.Zone{
+					{Zone: tado.Zone{ID: 1, Name: "Living room", Type: tado.ZoneTypeHeating}},
+					{Zone: tado.Zone{ID: 2, Name: "Bedroom", Type: tado.ZoneTypeHeating}},
+				},
+			})
+
+			var got []string
+			m := &Monitor{
+				Client: srv.Client(),
+				HomeID: 1,
+				Rules:  tt.rules,
+				Sinks: []notify.Notifier{notify.NotifierFunc(func(ctx context.Context, msg notify.Message) error {
+					got = append(got, msg.Title+": "+msg.Text)
+					return nil
+				})},
+				OnError: func(err error) {
+					t.Errorf("unexpected error: %v", err)
+				},
+				active: map[alarmKey]Alarm{},
+			}
+
+			for i, step := range tt.steps {
+				for zoneID, state := range step.states {
+					srv.SetZoneState(1, zoneID, state)
+				}
+
+				got = nil
+				m.poll(context.Background())
+
+				if !reflect.DeepEqual(got, step.want) {
+					t.Errorf("poll %d: got notifications %q, want %q", i+1, got, step.want)
+				}
+			}
+		})
+	}
+}
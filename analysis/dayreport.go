@@ -0,0 +1,50 @@
+package analysis
+
+import "github.com/idriesalbender/go-tado/tado"
+
+// InsideSamples returns the inside temperature samples of a day report.
+func InsideSamples(r *tado.DayReport) []Sample {
+	points := r.MeasuredData.InsideTemperature.DataPoints
+	samples := make([]Sample, 0, len(points))
+	for _, p := range points {
+		samples = append(samples, Sample{Time: p.Timestamp, Temperature: p.Value.Celsius})
+	}
+
+	return samples
+}
+
+// OutsideSamples returns the outside temperature samples of a day report,
+// taken from the start of its weather condition intervals.
+func OutsideSamples(r *tado.DayReport) []Sample {
+	intervals := r.Weather.Condition.DataIntervals
+	samples := make([]Sample, 0, len(intervals))
+	for _, i := range intervals {
+		samples = append(samples, Sample{Time: i.From, Temperature: i.Value.Temperature.Celsius})
+	}
+
+	return samples
+}
+
+// Intervals returns the call-for-heat intervals of a day report.
+func Intervals(r *tado.DayReport) []Interval {
+	if r.CallForHeat == nil {
+		return nil
+	}
+
+	intervals := make([]Interval, 0, len(r.CallForHeat.DataIntervals))
+	for _, i := range r.CallForHeat.DataIntervals {
+		intervals = append(intervals, Interval{
+			From:        i.From,
+			To:          i.To,
+			CallForHeat: i.Value != tado.CallForHeatNone,
+		})
+	}
+
+	return intervals
+}
+
+// RatesOfDayReport computes the heating and cooling rates of a zone from its
+// day report.
+func RatesOfDayReport(r *tado.DayReport) Rates {
+	return RatesOf(InsideSamples(r), Intervals(r))
+}
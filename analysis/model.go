@@ -0,0 +1,195 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// ThermalModel is a serializable summary of the learned thermal behaviour of
+// the rooms of a home, for consumption by external optimizers. It is fitted to
+// day reports using NewThermalModel or FetchThermalModel.
+type ThermalModel struct {
+	HomeID      tado.HomeID `json:"homeId"`
+	GeneratedAt time.Time   `json:"generatedAt"`
+	From        time.Time   `json:"from"`
+	To          time.Time   `json:"to"`
+	Rooms       []RoomModel `json:"rooms"`
+}
+
+// RoomModel is the thermal model of a single room.
+//
+// LossCoefficient is the rate at which the room cools down while idle, in
+// degrees Celsius per hour per degree of difference between the inside and
+// outside temperature. It is zero if it could not be determined.
+type RoomModel struct {
+	ZoneID          tado.ZoneID `json:"zoneId"`
+	Name            string      `json:"name"`
+	Rates           Rates       `json:"rates"`
+	LossCoefficient float64     `json:"lossCoefficient"`
+}
+
+// NewThermalModel fits a thermal model of the given zones of a home to their
+// day reports, keyed by zone ID. Zones without day reports are included with
+// unknown rates. The period of the model is the period covered by the
+// reports.
+func NewThermalModel(homeID tado.HomeID, zones []tado.Zone, reports map[tado.ZoneID][]*tado.DayReport) *ThermalModel {
+	m := &ThermalModel{HomeID: homeID, GeneratedAt: time.Now(), Rooms: []RoomModel{}}
+
+	for _, zone := range zones {
+		m.Rooms = append(m.Rooms, RoomModelOf(zone, reports[zone.ID]))
+
+		for _, r := range reports[zone.ID] {
+			if m.From.IsZero() || r.Interval.From.Before(m.From) {
+				m.From = r.Interval.From
+			}
+			if r.Interval.To.After(m.To) {
+				m.To = r.Interval.To
+			}
+		}
+	}
+
+	return m
+}
+
+// RoomModelOf fits the thermal model of a zone to its day reports.
+func RoomModelOf(zone tado.Zone, reports []*tado.DayReport) RoomModel {
+	var inside, outside []Sample
+	var intervals []Interval
+	for _, r := range reports {
+		inside = append(inside, InsideSamples(r)...)
+		outside = append(outside, OutsideSamples(r)...)
+		intervals = append(intervals, Intervals(r)...)
+	}
+
+	return RoomModel{
+		ZoneID:          zone.ID,
+		Name:            zone.Name,
+		Rates:           RatesOf(inside, intervals),
+		LossCoefficient: LossCoefficient(inside, outside, intervals),
+	}
+}
+
+// FetchThermalModel fits a thermal model of the heating zones of the home
+// with the given ID to their day reports of the days from from to to,
+// inclusive.
+func FetchThermalModel(ctx context.Context, client *tado.Client, homeID tado.HomeID, from, to time.Time) (*ThermalModel, error) {
+	zones, err := client.Zone.List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var heating []tado.Zone
+	reports := map[tado.ZoneID][]*tado.DayReport{}
+	for _, zone := range zones {
+		if zone.Type != tado.ZoneTypeHeating {
+			continue
+		}
+		heating = append(heating, zone)
+
+		for report, err := range client.Zone.DayReports(ctx, homeID, zone.ID, from, to) {
+			if err != nil {
+				return nil, fmt.Errorf("zone %d: %w", zone.ID, err)
+			}
+			reports[zone.ID] = append(reports[zone.ID], report)
+		}
+	}
+
+	return NewThermalModel(homeID, heating, reports), nil
+}
+
+// Room returns the model of the room with the given zone ID.
+func (m *ThermalModel) Room(zoneID tado.ZoneID) (*RoomModel, bool) {
+	for i := range m.Rooms {
+		if m.Rooms[i].ZoneID == zoneID {
+			return &m.Rooms[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Write writes the model as JSON to w.
+func (m *ThermalModel) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+// ReadThermalModel reads a model written by ThermalModel.Write from r.
+func ReadThermalModel(r io.Reader) (*ThermalModel, error) {
+	var m ThermalModel
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// LossCoefficient computes the heat loss coefficient of a room from its inside
+// temperature samples, the outside temperature samples and its call-for-heat
+// intervals. Only idle periods during which the room is warmer than outside
+// are taken into account.
+func LossCoefficient(inside, outside []Sample, intervals []Interval) float64 {
+	inside = append([]Sample(nil), inside...)
+	sort.Slice(inside, func(i, j int) bool {
+		return inside[i].Time.Before(inside[j].Time)
+	})
+
+	var weighted float64
+	var total time.Duration
+
+	for i := 1; i < len(inside); i++ {
+		prev, cur := inside[i-1], inside[i]
+
+		interval, ok := intervalOf(intervals, prev.Time, cur.Time)
+		if !ok || interval.CallForHeat {
+			continue
+		}
+
+		out, ok := nearest(outside, prev.Time)
+		if !ok {
+			continue
+		}
+
+		diff := prev.Temperature - out.Temperature
+		if diff <= 0 {
+			continue
+		}
+
+		d := cur.Time.Sub(prev.Time)
+		rate := (prev.Temperature - cur.Temperature) / d.Hours()
+		weighted += rate / diff * d.Hours()
+		total += d
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return weighted / total.Hours()
+}
+
+// nearest returns the sample closest in time to t.
+func nearest(samples []Sample, t time.Time) (Sample, bool) {
+	var best Sample
+	var bestDiff time.Duration = -1
+
+	for _, s := range samples {
+		diff := s.Time.Sub(t)
+		if diff < 0 {
+			diff = -diff
+		}
+
+		if bestDiff < 0 || diff < bestDiff {
+			best, bestDiff = s, diff
+		}
+	}
+
+	return best, bestDiff >= 0
+}
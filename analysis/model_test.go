@@ -0,0 +1,152 @@
+package analysis
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// dayReport is a day report idling from 00:00 to 02:00 and heating from 02:00
+// to 03:00, with an outside temperature of 10°C.
+const dayReport = `{
+	"interval": {"from": "2026-01-15T00:00:00Z", "to": "2026-01-16T00:00:00Z"},
+	"measuredData": {
+		"insideTemperature": {
+			"dataPoints": [
+				{"timestamp": "2026-01-15T00:00:00Z", "value": {"celsius": 20}},
+				{"timestamp": "2026-01-15T01:00:00Z", "value": {"celsius": 19}},
+				{"timestamp": "2026-01-15T02:00:00Z", "value": {"celsius": 18.1}},
+				{"timestamp": "2026-01-15T03:00:00Z", "value": {"celsius": 20.1}}
+			]
+		}
+	},
+	"callForHeat": {
+		"dataIntervals": [
+			{"from": "2026-01-15T00:00:00Z", "to": "2026-01-15T02:00:00Z", "value": "NONE"},
+			{"from": "2026-01-15T02:00:00Z", "to": "2026-01-15T03:00:00Z", "value": "HIGH"}
+		]
+	},
+	"weather": {
+		"condition": {
+			"dataIntervals": [
+				{"from": "2026-01-15T00:00:00Z", "to": "2026-01-15T03:00:00Z", "value": {"state": "CLOUDY", "temperature": {"celsius": 10}}}
+			]
+		}
+	}
+}`
+
+func TestLossCoefficient(t *testing.T) {
+	outside := []Sample{{at(0), 10}, {at(6), 25}}
+
+	tests := []struct {
+		name      string
+		inside    []Sample
+		intervals []Interval
+		want      float64
+	}{
+		{
+			name:      "idle",
+			inside:    []Sample{{at(0), 20}, {at(1), 19}, {at(2), 18.1}},
+			intervals: []Interval{{From: at(0), To: at(2)}},
+			want:      0.1,
+		},
+		{
+			name:      "weighted by duration",
+			inside:    []Sample{{at(0), 20}, {at(2), 18}, {at(2.5), 17.6}},
+			intervals: []Interval{{From: at(0), To: at(3)}},
+			want:      (0.1*2 + 0.1*0.5) / 2.5,
+		},
+		{
+			name:      "heating",
+			inside:    []Sample{{at(0), 18}, {at(1), 19}},
+			intervals: []Interval{{From: at(0), To: at(1), CallForHeat: true}},
+		},
+		{
+			name:      "colder than outside",
+			inside:    []Sample{{at(6), 24}, {at(7), 23}},
+			intervals: []Interval{{From: at(6), To: at(7)}},
+		},
+		{
+			name:   "without intervals",
+			inside: []Sample{{at(0), 20}, {at(1), 19}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LossCoefficient(tt.inside, outside, tt.intervals); !near(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewThermalModel(t *testing.T) {
+	var report tado.DayReport
+	if err := json.Unmarshal([]byte(dayReport), &report); err != nil {
+		t.Fatal(err)
+	}
+
+	zones := []tado.Zone{{ID: 1, Name: "Living room"}, {ID: 2, Name: "Bedroom"}}
+	m := NewThermalModel(1, zones, map[tado.ZoneID][]*tado.DayReport{1: {&report}})
+
+	if want := start; !m.From.Equal(want) {
+		t.Errorf("got from %v, want %v", m.From, want)
+	}
+	if want := start.AddDate(0, 0, 1); !m.To.Equal(want) {
+		t.Errorf("got to %v, want %v", m.To, want)
+	}
+
+	room, ok := m.Room(1)
+	if !ok {
+		t.Fatal("no model of zone 1")
+	}
+	if !near(room.Rates.Heating, 2) || room.Rates.HeatingDuration != time.Hour {
+		t.Errorf("got heating rate %v over %v, want 2 over 1h", room.Rates.Heating, room.Rates.HeatingDuration)
+	}
+	if !near(room.Rates.Cooling, -0.95) || room.Rates.CoolingDuration != 2*time.Hour {
+		t.Errorf("got cooling rate %v over %v, want -0.95 over 2h", room.Rates.Cooling, room.Rates.CoolingDuration)
+	}
+	if !near(room.LossCoefficient, 0.1) {
+		t.Errorf("got loss coefficient %v, want 0.1", room.LossCoefficient)
+	}
+
+	if room, ok := m.Room(2); !ok || room.Rates != (Rates{}) || room.LossCoefficient != 0 {
+		t.Errorf("got model %+v of zone 2 without reports, want unknown rates", room)
+	}
+	if _, ok := m.Room(3); ok {
+		t.Error("got a model of zone 3, want none")
+	}
+}
+
+func TestThermalModel_Write(t *testing.T) {
+	m := &ThermalModel{
+		HomeID:      1,
+		GeneratedAt: start.Add(12 * time.Hour),
+		From:        start,
+		To:          start.AddDate(0, 0, 1),
+		Rooms: []RoomModel{{
+			ZoneID:          1,
+			Name:            "Living room",
+			Rates:           Rates{Heating: 2, HeatingDuration: time.Hour, Cooling: -0.5, CoolingDuration: 2 * time.Hour},
+			LossCoefficient: 0.1,
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := m.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadThermalModel(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, m) {
+		t.Errorf("got %+v, want %+v", got, m)
+	}
+}
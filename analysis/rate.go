@@ -0,0 +1,123 @@
+// Package analysis provides helpers to analyse historical Tado data, such as
+// the heating and cooling rates of rooms.
+package analysis
+
+import (
+	"sort"
+	"time"
+)
+
+// Sample is a single temperature measurement of a room.
+type Sample struct {
+	Time        time.Time
+	Temperature float64
+}
+
+// Interval is a period of time during which a room was either calling for
+// heat or idle.
+type Interval struct {
+	From        time.Time
+	To          time.Time
+	CallForHeat bool
+}
+
+// Rates holds the average rate of temperature change of a room, in degrees
+// Celsius per hour, while calling for heat and while idle. The durations hold
+// the amount of time each rate is based on; a zero duration means the rate is
+// unknown.
+type Rates struct {
+	Heating         float64       `json:"heating"`
+	HeatingDuration time.Duration `json:"heatingDuration"`
+	Cooling         float64       `json:"cooling"`
+	CoolingDuration time.Duration `json:"coolingDuration"`
+}
+
+// RatesOf computes the heating and cooling rates of a room from its
+// temperature samples and call-for-heat intervals. Consecutive samples are
+// attributed to the interval containing both of them; pairs of samples
+// spanning an interval boundary are ignored.
+func RatesOf(samples []Sample, intervals []Interval) Rates {
+	samples = append([]Sample(nil), samples...)
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].Time.Before(samples[j].Time)
+	})
+
+	var rates Rates
+	var heatingDelta, coolingDelta float64
+
+	for i := 1; i < len(samples); i++ {
+		prev, cur := samples[i-1], samples[i]
+
+		interval, ok := intervalOf(intervals, prev.Time, cur.Time)
+		if !ok {
+			continue
+		}
+
+		d := cur.Time.Sub(prev.Time)
+		delta := cur.Temperature - prev.Temperature
+		if interval.CallForHeat {
+			heatingDelta += delta
+			rates.HeatingDuration += d
+		} else {
+			coolingDelta += delta
+			rates.CoolingDuration += d
+		}
+	}
+
+	if rates.HeatingDuration > 0 {
+		rates.Heating = heatingDelta / rates.HeatingDuration.Hours()
+	}
+	if rates.CoolingDuration > 0 {
+		rates.Cooling = coolingDelta / rates.CoolingDuration.Hours()
+	}
+
+	return rates
+}
+
+// Merge combines the rates of several periods, weighted by their durations.
+func Merge(rates ...Rates) Rates {
+	var merged Rates
+	var heatingDelta, coolingDelta float64
+
+	for _, r := range rates {
+		heatingDelta += r.Heating * r.HeatingDuration.Hours()
+		merged.HeatingDuration += r.HeatingDuration
+		coolingDelta += r.Cooling * r.CoolingDuration.Hours()
+		merged.CoolingDuration += r.CoolingDuration
+	}
+
+	if merged.HeatingDuration > 0 {
+		merged.Heating = heatingDelta / merged.HeatingDuration.Hours()
+	}
+	if merged.CoolingDuration > 0 {
+		merged.Cooling = coolingDelta / merged.CoolingDuration.Hours()
+	}
+
+	return merged
+}
+
+// PreheatDuration estimates how long a room heating at rate r needs to get
+// from the temperature from to the temperature to. It returns false if the
+// heating rate is unknown or not positive.
+func (r Rates) PreheatDuration(from, to float64) (time.Duration, bool) {
+	if r.HeatingDuration == 0 || r.Heating <= 0 {
+		return 0, false
+	}
+
+	if to <= from {
+		return 0, true
+	}
+
+	return time.Duration((to - from) / r.Heating * float64(time.Hour)), true
+}
+
+// intervalOf returns the interval containing both from and to.
+func intervalOf(intervals []Interval, from, to time.Time) (Interval, bool) {
+	for _, interval := range intervals {
+		if !from.Before(interval.From) && !to.After(interval.To) {
+			return interval, true
+		}
+	}
+
+	return Interval{}, false
+}
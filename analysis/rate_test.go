@@ -0,0 +1,154 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// start is the start of the fixed day the samples of the tests are taken on.
+var start = time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+// at returns the time the given number of hours after start.
+func at(hours float64) time.Time {
+	return start.Add(time.Duration(hours * float64(time.Hour)))
+}
+
+// near reports whether a and b are equal up to rounding errors.
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestRatesOf(t *testing.T) {
+	intervals := []Interval{
+		{From: at(0), To: at(2), CallForHeat: true},
+		{From: at(2), To: at(4)},
+	}
+
+	tests := []struct {
+		name    string
+		samples []Sample
+		want    Rates
+	}{
+		{
+			name: "heating and cooling",
+			samples: []Sample{
+				{at(0), 18}, {at(1), 19}, {at(2), 20}, {at(3), 19.5}, {at(4), 19},
+			},
+			want: Rates{Heating: 1, HeatingDuration: 2 * time.Hour, Cooling: -0.5, CoolingDuration: 2 * time.Hour},
+		},
+		{
+			name: "unsorted samples",
+			samples: []Sample{
+				{at(4), 19}, {at(0), 18}, {at(3), 19.5}, {at(2), 20}, {at(1), 19},
+			},
+			want: Rates{Heating: 1, HeatingDuration: 2 * time.Hour, Cooling: -0.5, CoolingDuration: 2 * time.Hour},
+		},
+		{
+			name: "samples spanning an interval boundary",
+			samples: []Sample{
+				{at(0), 18}, {at(1.5), 19.5}, {at(2.5), 19}, {at(3.5), 18.5},
+			},
+			want: Rates{Heating: 1, HeatingDuration: 90 * time.Minute, Cooling: -0.5, CoolingDuration: time.Hour},
+		},
+		{
+			name: "samples outside of the intervals",
+			samples: []Sample{
+				{at(5), 18}, {at(6), 17},
+			},
+		},
+		{
+			name:    "single sample",
+			samples: []Sample{{at(1), 19}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RatesOf(tt.samples, intervals)
+			if !near(got.Heating, tt.want.Heating) || got.HeatingDuration != tt.want.HeatingDuration ||
+				!near(got.Cooling, tt.want.Cooling) || got.CoolingDuration != tt.want.CoolingDuration {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		rates []Rates
+		want  Rates
+	}{
+		{
+			name: "weighted by duration",
+			rates: []Rates{
+				{Heating: 1, HeatingDuration: 3 * time.Hour, Cooling: -1, CoolingDuration: time.Hour},
+				{Heating: 3, HeatingDuration: time.Hour},
+			},
+			want: Rates{Heating: 1.5, HeatingDuration: 4 * time.Hour, Cooling: -1, CoolingDuration: time.Hour},
+		},
+		{
+			name:  "unknown rates",
+			rates: []Rates{{}, {}},
+		},
+		{
+			name: "none",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Merge(tt.rates...); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRates_PreheatDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		rates    Rates
+		from, to float64
+		want     time.Duration
+		wantOK   bool
+	}{
+		{
+			name:   "heating",
+			rates:  Rates{Heating: 2, HeatingDuration: time.Hour},
+			from:   18,
+			to:     21,
+			want:   90 * time.Minute,
+			wantOK: true,
+		},
+		{
+			name:   "already warm enough",
+			rates:  Rates{Heating: 2, HeatingDuration: time.Hour},
+			from:   21,
+			to:     20,
+			wantOK: true,
+		},
+		{
+			name:  "unknown heating rate",
+			rates: Rates{Heating: 2},
+			from:  18,
+			to:    21,
+		},
+		{
+			name:  "not heating up",
+			rates: Rates{Heating: -0.5, HeatingDuration: time.Hour},
+			from:  18,
+			to:    21,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.rates.PreheatDuration(tt.from, tt.to)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("got %v, %t, want %v, %t", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// Package apicheck detects changes of the Tado API early, by periodically
+// comparing the shape of the responses of known endpoints against the shapes
+// embedded in this package, which match the models of the tado package.
+//
+// New fields and fields whose JSON type changed are reported, so that
+// maintainers and users are warned about upstream changes before they break
+// decoding. Missing fields are not reported, as many fields are optional.
+package apicheck
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+//go:embed schemas/*.json
+var schemas embed.FS
+
+// Endpoint is an endpoint of the Tado API checked by a Detector.
+type Endpoint struct {
+	// Name is the name of the endpoint and its embedded schema.
+	Name string
+	// Path returns the path of the endpoint for the given home ID.
+	Path func(homeID tado.HomeID) string
+}
+
+// Endpoints are the endpoints checked by default.
+var Endpoints = []Endpoint{
+	{Name: "me", Path: func(tado.HomeID) string { return "me" }},
+	{Name: "home", Path: func(id tado.HomeID) string { return fmt.Sprintf("homes/%d", id) }},
+	{Name: "state", Path: func(id tado.HomeID) string { return fmt.Sprintf("homes/%d/state", id) }},
+	{Name: "weather", Path: func(id tado.HomeID) string { return fmt.Sprintf("homes/%d/weather", id) }},
+	{Name: "zones", Path: func(id tado.HomeID) string { return fmt.Sprintf("homes/%d/zones", id) }},
+}
+
+// ChangeKind represents the kind of a change.
+type ChangeKind string
+
+const (
+	// Added changes are fields or structures the schema does not know.
+	Added ChangeKind = "ADDED"
+	// TypeChanged changes are fields whose JSON type differs from the schema.
+	TypeChanged ChangeKind = "TYPE_CHANGED"
+)
+
+// Change is a difference between a response and the schema of its endpoint.
+// Path is the path of the field, with object keys separated by dots and
+// array elements denoted by "[]", e.g. "devices[].serialNo". Want is the type
+// in the schema and Got the type in the response, one of "string", "number",
+// "boolean", "object" and "array".
+type Change struct {
+	Endpoint string
+	Kind     ChangeKind
+	Path     string
+	Want     string
+	Got      string
+}
+
+// String returns a human-readable description of the change.
+func (c Change) String() string {
+	if c.Kind == Added {
+		return fmt.Sprintf("%s: new %s field %s", c.Endpoint, c.Got, c.Path)
+	}
+
+	return fmt.Sprintf("%s: field %s changed from %s to %s", c.Endpoint, c.Path, c.Want, c.Got)
+}
+
+// DefaultInterval is the default interval at which a Detector checks the
+// endpoints.
+var DefaultInterval = time.Hour
+
+// Detector periodically checks the endpoints of a home for changes. A change
+// is only reported once per Detector. Interval defaults to DefaultInterval.
+type Detector struct {
+	Client   *tado.Client
+	HomeID   tado.HomeID
+	Interval time.Duration
+
+	// Endpoints are the endpoints to check. If nil, Endpoints is used.
+	Endpoints []Endpoint
+
+	// OnChange is called with every change found, if not nil.
+	OnChange func(Change)
+	// OnError is called with errors fetching endpoints, if not nil.
+	OnError func(error)
+
+	reported map[Change]bool
+}
+
+// Run checks the endpoints at the configured interval until ctx is done.
+// Requests are sent with low priority, so that the checks do not delay other
+// requests of the client.
+func (d *Detector) Run(ctx context.Context) error {
+	ctx = tado.ContextWithPriority(ctx, tado.PriorityLow)
+
+	interval := d.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		d.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll checks all endpoints once and reports new changes.
+func (d *Detector) poll(ctx context.Context) {
+	if d.reported == nil {
+		d.reported = map[Change]bool{}
+	}
+
+	changes, err := d.Check(ctx)
+	if err != nil && d.OnError != nil {
+		d.OnError(err)
+	}
+
+	for _, change := range changes {
+		if d.reported[change] {
+			continue
+		}
+		d.reported[change] = true
+
+		if d.OnChange != nil {
+			d.OnChange(change)
+		}
+	}
+}
+
+// Check checks all endpoints once and returns the changes found. If some
+// endpoints cannot be checked, the changes of the others are returned together
+// with a *tado.MultiError.
+func (d *Detector) Check(ctx context.Context) ([]Change, error) {
+	endpoints := d.Endpoints
+	if endpoints == nil {
+		endpoints = Endpoints
+	}
+
+	var changes []Change
+	errs := &tado.MultiError{}
+	for _, endpoint := range endpoints {
+		found, err := d.check(ctx, endpoint)
+		if err != nil {
+			errs.Add(endpoint.Name, err)
+			continue
+		}
+		changes = append(changes, found...)
+	}
+
+	return changes, errs.ErrorOrNil()
+}
+
+// check fetches a single endpoint and compares its response to its schema.
+func (d *Detector) check(ctx context.Context, endpoint Endpoint) ([]Change, error) {
+	schema, err := loadSchema(endpoint.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := d.Client.NewRequest("GET", endpoint.Path(d.HomeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body any
+	if _, err := d.Client.Do(ctx, req, &body); err != nil {
+		return nil, err
+	}
+
+	return Compare(endpoint.Name, schema, body), nil
+}
+
+// loadSchema returns the embedded schema with the given name.
+func loadSchema(name string) (map[string]string, error) {
+	data, err := schemas.ReadFile("schemas/" + name + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("no schema for endpoint %s", name)
+	}
+
+	var schema map[string]string
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("invalid schema for endpoint %s: %w", name, err)
+	}
+
+	return schema, nil
+}
+
+// Compare returns the changes of body, a decoded JSON document, relative to
+// schema, which maps the paths of the known fields to their types. Types of
+// "any" match any value, and a path segment of "*" matches any key of an
+// object. The changes are ordered by path.
+func Compare(endpoint string, schema map[string]string, body any) []Change {
+	var changes []Change
+
+	var walk func(v any, path string)
+	walk = func(v any, path string) {
+		got := typeOf(v)
+		if got == "null" {
+			return
+		}
+
+		if path != "" {
+			want, ok := schema[path]
+			switch {
+			case !ok:
+				changes = append(changes, Change{Endpoint: endpoint, Kind: Added, Path: path, Got: got})
+				return
+			case want == "any":
+				return
+			case want != got:
+				changes = append(changes, Change{Endpoint: endpoint, Kind: TypeChanged, Path: path, Want: want, Got: got})
+				return
+			}
+		}
+
+		switch v := v.(type) {
+		case map[string]any:
+			_, wildcard := schema[join(path, "*")]
+			for key, child := range v {
+				if wildcard {
+					key = "*"
+				}
+				walk(child, join(path, key))
+			}
+		case []any:
+			for _, child := range v {
+				walk(child, path+"[]")
+			}
+		}
+	}
+	walk(body, "")
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return slices.Compact(changes)
+}
+
+// typeOf returns the JSON type of a decoded JSON value.
+func typeOf(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// join joins the path of an object and one of its keys.
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
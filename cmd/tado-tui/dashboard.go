@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// termination is a choice of when manual changes end.
+type termination struct {
+	name        string
+	termination tado.Termination
+}
+
+// terminations are the choices cycled through with the t key.
+var terminations = []termination{
+	{"until the next block", tado.NextTimeBlockTermination()},
+	{"for one hour", tado.TimerTermination(time.Hour)},
+	{"until resumed", tado.ManualTermination()},
+}
+
+// dashboard is the state of the dashboard.
+type dashboard struct {
+	client *tado.Client
+	homeID tado.HomeID
+	out    io.Writer
+
+	snapshot    *tado.HomeSnapshot
+	updated     time.Time
+	selected    int
+	termination int
+	status      string
+}
+
+// newDashboard returns a dashboard of the home with the given ID, rendered to
+// out.
+func newDashboard(client *tado.Client, homeID tado.HomeID, out io.Writer) *dashboard {
+	fmt.Fprint(out, "\x1b[?25l") // hide the cursor
+	return &dashboard{client: client, homeID: homeID, out: out}
+}
+
+// close restores the terminal.
+func (d *dashboard) close() {
+	fmt.Fprint(d.out, "\x1b[?25h\x1b[2J\x1b[H")
+}
+
+// run handles the given keys and refreshes the dashboard at the given interval
+// until the user quits or ctx is done.
+func (d *dashboard) run(ctx context.Context, keys <-chan key, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	d.refresh(ctx)
+	for {
+		d.render()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			d.refresh(ctx)
+		case k, ok := <-keys:
+			if !ok || k == keyQuit || k == "q" {
+				return nil
+			}
+			d.handle(ctx, k)
+		}
+	}
+}
+
+// refresh fetches a new snapshot of the home. Background refreshes have a low
+// priority, so that overlays are not delayed by the rate limiter.
+func (d *dashboard) refresh(ctx context.Context) {
+	snapshot, err := d.client.Home.Snapshot(tado.ContextWithPriority(ctx, tado.PriorityLow), d.homeID)
+	if snapshot == nil {
+		d.status = fmt.Sprintf("refresh failed: %v", err)
+		return
+	}
+
+	d.snapshot, d.updated = snapshot, time.Now()
+	d.selected = min(d.selected, max(len(snapshot.Rooms)-1, 0))
+	if err != nil {
+		d.status = fmt.Sprintf("some rooms failed: %v", err)
+	}
+}
+
+// handle handles a key pressed by the user.
+func (d *dashboard) handle(ctx context.Context, k key) {
+	d.status = ""
+
+	switch k {
+	case keyUp, "k":
+		d.selected = max(d.selected-1, 0)
+	case keyDown, "j":
+		if d.snapshot != nil {
+			d.selected = min(d.selected+1, max(len(d.snapshot.Rooms)-1, 0))
+		}
+	case "t":
+		d.termination = (d.termination + 1) % len(terminations)
+	case " ":
+		d.refresh(ctx)
+	case "+", "=":
+		d.adjust(ctx, 1)
+	case "-":
+		d.adjust(ctx, -1)
+	case "o":
+		d.overlay(ctx, tado.OffSetting(tado.ZoneTypeHeating))
+	case "r":
+		d.resume(ctx)
+	case "h":
+		d.presence(ctx, tado.PresenceHome)
+	case "a":
+		d.presence(ctx, tado.PresenceAway)
+	case "g":
+		d.do(ctx, "presence follows geofencing", func() error {
+			return d.client.Home.DeletePresenceLock(ctx, d.homeID)
+		})
+	}
+}
+
+// room returns the selected room, if any.
+func (d *dashboard) room() (tado.RoomSnapshot, bool) {
+	if d.snapshot == nil || d.selected >= len(d.snapshot.Rooms) {
+		return tado.RoomSnapshot{}, false
+	}
+
+	return d.snapshot.Rooms[d.selected], true
+}
+
+// adjust raises or lowers the setpoint of the selected room by the given
+// number of steps of half a degree Celsius or one degree Fahrenheit.
+func (d *dashboard) adjust(ctx context.Context, steps int) {
+	room, ok := d.room()
+	if !ok {
+		return
+	}
+
+	step := 0.5
+	if d.snapshot.Unit == tado.UnitFahrenheit {
+		step = 1
+	}
+
+	target := room.Temperature
+	if room.Target != nil {
+		target = *room.Target
+	}
+	target += float64(steps) * step
+
+	d.do(ctx, fmt.Sprintf("%s set to %.1f%s", room.Name, target, d.snapshot.Unit.Symbol()), func() error {
+		_, err := d.client.Zone.SetTemperature(ctx, d.homeID, room.ZoneID, target, terminations[d.termination].termination)
+		return err
+	})
+}
+
+// overlay sets an overlay with the given setting on the selected room.
+func (d *dashboard) overlay(ctx context.Context, setting tado.ZoneSetting) {
+	room, ok := d.room()
+	if !ok {
+		return
+	}
+
+	d.do(ctx, fmt.Sprintf("%s switched off", room.Name), func() error {
+		overlay := tado.NewOverlay(setting, terminations[d.termination].termination)
+		_, err := d.client.Zone.SetOverlay(ctx, d.homeID, room.ZoneID, overlay)
+		return err
+	})
+}
+
+// resume removes the overlay of the selected room.
+func (d *dashboard) resume(ctx context.Context) {
+	room, ok := d.room()
+	if !ok {
+		return
+	}
+
+	d.do(ctx, fmt.Sprintf("%s follows its schedule", room.Name), func() error {
+		return d.client.Zone.DeleteOverlay(ctx, d.homeID, room.ZoneID)
+	})
+}
+
+// presence locks the presence of the home.
+func (d *dashboard) presence(ctx context.Context, presence tado.Presence) {
+	d.do(ctx, fmt.Sprintf("presence set to %s", presence), func() error {
+		return d.client.Home.SetPresenceLock(ctx, d.homeID, presence)
+	})
+}
+
+// do runs the given write, and refreshes the dashboard when it succeeds.
+func (d *dashboard) do(ctx context.Context, done string, write func() error) {
+	d.status = "…"
+	d.render()
+
+	if err := write(); err != nil {
+		d.status = err.Error()
+		return
+	}
+
+	d.refresh(ctx)
+	d.status = done
+}
+
+// render draws the dashboard.
+func (d *dashboard) render() {
+	var b strings.Builder
+
+	b.WriteString("\x1b[H\x1b[2J")
+	line := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+		b.WriteString("\r\n")
+	}
+
+	if d.snapshot == nil {
+		line("loading…")
+	} else {
+		symbol := d.snapshot.Unit.Symbol()
+
+		title := fmt.Sprintf("home %d", d.homeID)
+		if d.snapshot.Home != nil {
+			title = d.snapshot.Home.Name
+		}
+		if d.snapshot.State != nil {
+			title += fmt.Sprintf(" — %s", d.snapshot.State.Presence)
+			if d.snapshot.State.PresenceLocked {
+				title += " (locked)"
+			}
+		}
+		if d.snapshot.Weather != nil {
+			outside := d.snapshot.Weather.OutsideTemperature.In(d.snapshot.Unit)
+			title += fmt.Sprintf(", outside %.1f%s", outside, symbol)
+		}
+		line("\x1b[1m%s\x1b[0m", title)
+		line("")
+
+		for i, room := range d.snapshot.Rooms {
+			cursor := "  "
+			if i == d.selected {
+				cursor = "> "
+			}
+
+			target := "off"
+			if room.Target != nil {
+				target = fmt.Sprintf("%.1f%s", *room.Target, symbol)
+			}
+
+			line("%s%-20s %6.1f%s → %-8s %3.0f%%", cursor, room.Name, room.Temperature, symbol, target, room.Humidity)
+		}
+
+		line("")
+		line("updated %s, manual changes last %s", d.updated.Format(time.TimeOnly), terminations[d.termination].name)
+	}
+
+	line("%s", d.status)
+	line("")
+	line("↑/↓ select  +/- setpoint  o off  r resume  t termination  h/a/g presence  space refresh  q quit")
+
+	fmt.Fprint(d.out, b.String())
+}
@@ -0,0 +1,16 @@
+module github.com/idriesalbender/go-tado/cmd/tado-tui
+
+go 1.23.5
+
+require (
+	github.com/idriesalbender/go-tado v0.0.0
+	golang.org/x/term v0.27.0
+)
+
+require (
+	golang.org/x/oauth2 v0.25.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+	golang.org/x/time v0.9.0 // indirect
+)
+
+replace github.com/idriesalbender/go-tado => ../../
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bufio"
+	"io"
+)
+
+// key is a key pressed by the user.
+type key string
+
+const (
+	keyUp   key = "up"
+	keyDown key = "down"
+	keyQuit key = "quit"
+)
+
+// readKeys returns a channel receiving the keys read from r, which must be a
+// terminal in raw mode. The channel is closed when r is exhausted.
+func readKeys(r io.Reader) <-chan key {
+	keys := make(chan key)
+
+	go func() {
+		defer close(keys)
+
+		br := bufio.NewReader(r)
+		for {
+			b, err := br.ReadByte()
+			if err != nil {
+				return
+			}
+
+			switch b {
+			case 0x03: // ctrl-c
+				keys <- keyQuit
+			case 0x1b: // escape sequence of an arrow key
+				if next, err := br.ReadByte(); err != nil || next != '[' {
+					continue
+				}
+				switch arrow, _ := br.ReadByte(); arrow {
+				case 'A':
+					keys <- keyUp
+				case 'B':
+					keys <- keyDown
+				}
+			default:
+				keys <- key(string(rune(b)))
+			}
+		}
+	}()
+
+	return keys
+}
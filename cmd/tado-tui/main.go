@@ -0,0 +1,78 @@
+// Command tado-tui is a terminal dashboard for a Tado home, showing its zones,
+// their temperatures and setpoints and the presence of the home.
+//
+// It is kept in a separate module so that the library does not depend on
+// terminal packages. Once installed, it is also available as "tado tui".
+//
+// Keys:
+//
+//	↑/↓, k/j  select a zone
+//	+/-       raise or lower the setpoint of the selected zone
+//	o         switch the selected zone off
+//	r         resume the schedule of the selected zone
+//	t         change when manual changes end
+//	h/a/g     set the presence to home, away or geofencing
+//	space     refresh
+//	q         quit
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+	"golang.org/x/term"
+)
+
+func main() {
+	if err := run(context.Background()); err != nil {
+		fmt.Fprintf(os.Stderr, "tado-tui: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the dashboard until the user quits.
+func run(ctx context.Context) error {
+	configPath := flag.String("config", "", "path of the JSON config file")
+	interval := flag.Duration("interval", time.Minute, "refresh interval")
+	flag.Parse()
+
+	config, err := tado.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	if config.HomeID == 0 {
+		return fmt.Errorf("no home ID configured, set TADO_HOME_ID")
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		return err
+	}
+
+	client, err := tado.NewClientWithContext(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	// authenticate before switching to raw mode, so that the device flow
+	// prompt is readable
+	if _, err := client.User.Get(ctx); err != nil {
+		return err
+	}
+
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	d := newDashboard(client, config.HomeID, os.Stdout)
+	defer d.close()
+
+	return d.run(ctx, readKeys(os.Stdin), *interval)
+}
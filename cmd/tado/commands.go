@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// command is a command of the CLI. Its run function may return a partial
+// result together with an error, e.g. a *tado.MultiError, in which case the
+// result is printed before the error is reported.
+type command struct {
+	description string
+	run         func(ctx context.Context, client *tado.Client, homeID tado.HomeID) (*result, error)
+}
+
+// commands are the commands of the CLI by name.
+var commands = map[string]command{
+	"home":    {"show the presence and weather of the home", runHome},
+	"zones":   {"list the heating zones and their temperatures", runZones},
+	"devices": {"list the devices and their state", runDevices},
+}
+
+// homeV1 is the tado.home/v1 schema.
+type homeV1 struct {
+	ID                 tado.HomeID          `json:"id" yaml:"id"`
+	Name               string               `json:"name" yaml:"name"`
+	Presence           tado.Presence        `json:"presence" yaml:"presence"`
+	Unit               tado.TemperatureUnit `json:"unit" yaml:"unit"`
+	OutsideTemperature *float64             `json:"outsideTemperature" yaml:"outsideTemperature"`
+	Weather            string               `json:"weather" yaml:"weather"`
+}
+
+// zoneV1 is an item of the tado.zones/v1 schema.
+type zoneV1 struct {
+	ID          tado.ZoneID          `json:"id" yaml:"id"`
+	Name        string               `json:"name" yaml:"name"`
+	Unit        tado.TemperatureUnit `json:"unit" yaml:"unit"`
+	Temperature float64              `json:"temperature" yaml:"temperature"`
+	Target      *float64             `json:"target" yaml:"target"`
+	Humidity    float64              `json:"humidity" yaml:"humidity"`
+}
+
+// deviceV1 is an item of the tado.devices/v1 schema.
+type deviceV1 struct {
+	Serial   tado.DeviceSerial `json:"serial" yaml:"serial"`
+	Type     string            `json:"type" yaml:"type"`
+	Firmware string            `json:"firmware" yaml:"firmware"`
+	Zone     *tado.ZoneID      `json:"zone" yaml:"zone"`
+	Online   bool              `json:"online" yaml:"online"`
+	Battery  string            `json:"battery" yaml:"battery"`
+}
+
+// runHome runs the home command.
+func runHome(ctx context.Context, client *tado.Client, homeID tado.HomeID) (*result, error) {
+	snapshot, err := client.Home.Snapshot(ctx, homeID)
+	if snapshot == nil {
+		return nil, err
+	}
+
+	home := homeV1{ID: homeID, Unit: snapshot.Unit}
+	if snapshot.Home != nil {
+		home.Name = snapshot.Home.Name
+	}
+	if snapshot.State != nil {
+		home.Presence = snapshot.State.Presence
+	}
+	if snapshot.Weather != nil {
+		outside := snapshot.Weather.OutsideTemperature.In(snapshot.Unit)
+		home.OutsideTemperature = &outside
+		home.Weather = snapshot.Weather.WeatherState.Value
+	}
+
+	return &result{
+		Schema: "tado.home/v1",
+		Data:   home,
+		header: []string{"ID", "NAME", "PRESENCE", "OUTSIDE", "WEATHER"},
+		rows: [][]string{{
+			strconv.Itoa(int(home.ID)),
+			home.Name,
+			string(home.Presence),
+			optional("%.1f"+home.Unit.Symbol(), home.OutsideTemperature),
+			home.Weather,
+		}},
+	}, err
+}
+
+// runZones runs the zones command.
+func runZones(ctx context.Context, client *tado.Client, homeID tado.HomeID) (*result, error) {
+	snapshot, err := client.Home.Snapshot(ctx, homeID)
+	if snapshot == nil {
+		return nil, err
+	}
+
+	res := &result{
+		Schema: "tado.zones/v1",
+		header: []string{"ID", "NAME", "TEMPERATURE", "TARGET", "HUMIDITY"},
+	}
+
+	symbol := snapshot.Unit.Symbol()
+	zones := []zoneV1{}
+	for _, room := range snapshot.Rooms {
+		zones = append(zones, zoneV1{
+			ID:          room.ZoneID,
+			Name:        room.Name,
+			Unit:        snapshot.Unit,
+			Temperature: room.Temperature,
+			Target:      room.Target,
+			Humidity:    room.Humidity,
+		})
+		res.rows = append(res.rows, []string{
+			strconv.Itoa(int(room.ZoneID)),
+			room.Name,
+			fmt.Sprintf("%.1f%s", room.Temperature, symbol),
+			optional("%.1f"+symbol, room.Target),
+			fmt.Sprintf("%.0f%%", room.Humidity),
+		})
+	}
+	res.Data = zones
+
+	return res, err
+}
+
+// runDevices runs the devices command.
+func runDevices(ctx context.Context, client *tado.Client, homeID tado.HomeID) (*result, error) {
+	entries, err := client.Device.GetDeviceList(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &result{
+		Schema: "tado.devices/v1",
+		header: []string{"SERIAL", "TYPE", "FIRMWARE", "ZONE", "ONLINE", "BATTERY"},
+	}
+
+	devices := []deviceV1{}
+	for _, entry := range entries {
+		device := deviceV1{
+			Serial:   entry.Device.SerialNo,
+			Type:     entry.Device.DeviceType,
+			Firmware: entry.Device.CurrentFwVersion,
+			Online:   entry.Device.IsOnline(),
+			Battery:  string(entry.Device.BatteryState),
+		}
+		if entry.Zone != nil {
+			device.Zone = &entry.Zone.Discriminator
+		}
+
+		devices = append(devices, device)
+		res.rows = append(res.rows, []string{
+			string(device.Serial),
+			device.Type,
+			device.Firmware,
+			optional("%d", device.Zone),
+			strconv.FormatBool(device.Online),
+			device.Battery,
+		})
+	}
+	res.Data = devices
+
+	return res, nil
+}
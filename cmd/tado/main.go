@@ -0,0 +1,121 @@
+// Command tado is a command line interface to the Tado API.
+//
+// Usage:
+//
+//	tado [-config file] [-output json|yaml|table] <command>
+//
+// The home is selected using the TADO_HOME_ID environment variable or the
+// homeId of the config file, see tado.LoadConfig.
+//
+// The json and yaml output of every command is wrapped in an envelope naming
+// its schema, e.g. "tado.zones/v1". Fields are only added to a schema version;
+// renaming or removing a field introduces a new version, so that scripts
+// built on the output keep working between releases.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "tado: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run runs the CLI with the given arguments.
+func run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tado", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path of the JSON config file")
+	format := fs.String("output", string(formatTable), "output format: json, yaml or table")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: tado [flags] <command>\n\nCommands:\n")
+		for _, name := range slices.Sorted(maps.Keys(commands)) {
+			fmt.Fprintf(fs.Output(), "  %-10s %s\n", name, commands[name].description)
+		}
+		fmt.Fprintf(fs.Output(), "  %-10s %s\n", "tui", "open the dashboard (requires tado-tui)")
+		fmt.Fprintf(fs.Output(), "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	output := outputFormat(strings.ToLower(*format))
+	if !output.valid() {
+		return fmt.Errorf("invalid output format %q", *format)
+	}
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return fmt.Errorf("expected exactly one command")
+	}
+
+	if fs.Arg(0) == "tui" {
+		return runTUI(ctx, *configPath)
+	}
+
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+
+	config, err := tado.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	if config.HomeID == 0 {
+		return fmt.Errorf("no home ID configured, set TADO_HOME_ID")
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		return err
+	}
+
+	client, err := tado.NewClientWithContext(ctx, opts...)
+	if err != nil {
+		return err
+	}
+
+	res, err := cmd.run(ctx, client, config.HomeID)
+	if res != nil {
+		if err := res.write(os.Stdout, output); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+// runTUI runs the terminal dashboard, which is a separate command so that the
+// CLI does not depend on terminal packages.
+func runTUI(ctx context.Context, configPath string) error {
+	path, err := exec.LookPath("tado-tui")
+	if err != nil {
+		return fmt.Errorf("tado-tui not found, install github.com/idriesalbender/go-tado/cmd/tado-tui: %w", err)
+	}
+
+	var args []string
+	if configPath != "" {
+		args = append(args, "-config", configPath)
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// outputFormat is the format the result of a command is written in.
+type outputFormat string
+
+const (
+	formatJSON  outputFormat = "json"
+	formatYAML  outputFormat = "yaml"
+	formatTable outputFormat = "table"
+)
+
+// valid reports whether f is a known output format.
+func (f outputFormat) valid() bool {
+	switch f {
+	case formatJSON, formatYAML, formatTable:
+		return true
+	default:
+		return false
+	}
+}
+
+// result is the result of a command. Schema names the versioned schema of
+// Data, which is what the json and yaml formats write; the table format
+// writes the header and rows.
+type result struct {
+	Schema string `json:"schema" yaml:"schema"`
+	Data   any    `json:"data" yaml:"data"`
+
+	header []string
+	rows   [][]string
+}
+
+// write writes the result to w in the given format.
+func (r *result) write(w io.Writer, format outputFormat) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case formatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+		return enc.Close()
+	case formatTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(r.header, "\t"))
+		for _, row := range r.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("invalid output format %q", format)
+	}
+}
+
+// optional formats v with the given verb, or returns "-" if v is nil.
+func optional[T any](verb string, v *T) string {
+	if v == nil {
+		return "-"
+	}
+
+	return fmt.Sprintf(verb, *v)
+}
@@ -0,0 +1,169 @@
+// Command tadod is a daemon that evaluates per-zone temperature and humidity
+// thresholds continuously and delivers notifications when they are crossed,
+// see package alarm.
+//
+// Usage:
+//
+//	tadod -config file
+//
+// The config file is the JSON config of the client, see tado.LoadConfig,
+// extended with an "alarms" object holding the rules and notification sinks:
+//
+//	{
+//		"homeId": 12345,
+//		"tokenFile": "/var/lib/tadod/token.json",
+//		"alarms": {
+//			"interval": "5m",
+//			"rules": [
+//				{"zoneId": 1, "minTemperature": 16, "maxHumidity": 65},
+//				{"zoneId": 2, "maxTemperature": 80, "unit": "FAHRENHEIT"}
+//			],
+//			"slack": {"webhookUrl": "https://hooks.slack.com/services/..."},
+//			"telegram": {"token": "...", "chatId": "..."}
+//		}
+//	}
+//
+// The secrets of the sinks can be left out of the file and set using the
+// TADOD_SLACK_WEBHOOK_URL and TADOD_TELEGRAM_TOKEN environment variables
+// instead.
+package main
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/idriesalbender/go-tado/alarm"
+	"github.com/idriesalbender/go-tado/notify"
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "tadod: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// config is the part of the config file read by the daemon.
+type config struct {
+	Alarms struct {
+		Interval string       `json:"interval,omitempty"`
+		Rules    []alarm.Rule `json:"rules"`
+		Slack    *struct {
+			WebhookURL string `json:"webhookUrl"`
+		} `json:"slack,omitempty"`
+		Telegram *struct {
+			Token  string `json:"token"`
+			ChatID string `json:"chatId"`
+		} `json:"telegram,omitempty"`
+	} `json:"alarms"`
+}
+
+// loadConfig loads the alarms of the config file at path and builds the
+// monitor evaluating them, without its client.
+func loadConfig(path string) (*alarm.Monitor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	m := &alarm.Monitor{Rules: c.Alarms.Rules}
+	if len(m.Rules) == 0 {
+		return nil, fmt.Errorf("no alarm rules configured in %s", path)
+	}
+
+	if c.Alarms.Interval != "" {
+		m.Interval, err = time.ParseDuration(c.Alarms.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alarm interval %q: %w", c.Alarms.Interval, err)
+		}
+	}
+
+	if s := c.Alarms.Slack; s != nil {
+		m.Sinks = append(m.Sinks, &notify.Slack{
+			WebhookURL: cmp.Or(os.Getenv("TADOD_SLACK_WEBHOOK_URL"), s.WebhookURL),
+		})
+	}
+
+	if t := c.Alarms.Telegram; t != nil {
+		m.Sinks = append(m.Sinks, &notify.Telegram{
+			Token:  cmp.Or(os.Getenv("TADOD_TELEGRAM_TOKEN"), t.Token),
+			ChatID: t.ChatID,
+		})
+	}
+
+	if len(m.Sinks) == 0 {
+		return nil, fmt.Errorf("no notification sinks configured in %s", path)
+	}
+
+	return m, nil
+}
+
+// run runs the daemon with the given arguments until ctx is done.
+func run(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("tadod", flag.ContinueOnError)
+	configPath := fs.String("config", "", "path of the JSON config file")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if *configPath == "" {
+		fs.Usage()
+		return fmt.Errorf("no config file given")
+	}
+
+	monitor, err := loadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+
+	config, err := tado.LoadConfig(*configPath)
+	if err != nil {
+		return err
+	}
+	if config.HomeID == 0 {
+		return fmt.Errorf("no home ID configured, set TADO_HOME_ID")
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		return err
+	}
+
+	monitor.Client, err = tado.NewClientWithContext(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	monitor.HomeID = config.HomeID
+	monitor.OnError = func(err error) {
+		log.Printf("tadod: %v", err)
+	}
+
+	log.Printf("tadod: evaluating %d alarm rules of home %d", len(monitor.Rules), monitor.HomeID)
+
+	if err := monitor.Run(ctx); !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
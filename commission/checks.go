@@ -0,0 +1,143 @@
+package commission
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/idriesalbender/go-tado/config"
+)
+
+// DefaultChecklist is the default checklist of Run.
+var DefaultChecklist = []Check{
+	DevicesOnline,
+	DevicesCalibrated,
+	ZonesNamed,
+	SchedulesApplied,
+	OffsetsSet,
+	PresenceConfigured,
+}
+
+// DevicesOnline checks that all devices of the home are connected.
+var DevicesOnline = Check{
+	Name: "devices online",
+	Run: func(home *Home) ([]string, error) {
+		var issues []string
+		for _, device := range home.Devices {
+			if !device.IsOnline() {
+				issues = append(issues, fmt.Sprintf("device %s (%s) is offline", device.SerialNo, device.DeviceType))
+			}
+		}
+
+		return issues, nil
+	},
+}
+
+// DevicesCalibrated checks that all radiator valves of the home are mounted
+// and calibrated.
+var DevicesCalibrated = Check{
+	Name: "devices calibrated",
+	Run: func(home *Home) ([]string, error) {
+		var issues []string
+		for _, device := range home.Devices {
+			switch {
+			case device.NeedsRemounting():
+				issues = append(issues, fmt.Sprintf("device %s is not mounted", device.SerialNo))
+			case device.NeedsCalibration():
+				issues = append(issues, fmt.Sprintf("device %s is not calibrated", device.SerialNo))
+			}
+		}
+
+		return issues, nil
+	},
+}
+
+// ZonesNamed checks that all zones of the home have a unique, non-empty name.
+var ZonesNamed = Check{
+	Name: "zones named",
+	Run: func(home *Home) ([]string, error) {
+		var issues []string
+		seen := map[string]bool{}
+		for _, zone := range home.Zones {
+			name := strings.TrimSpace(zone.Name)
+			switch {
+			case name == "":
+				issues = append(issues, fmt.Sprintf("zone %d has no name", zone.ID))
+			case seen[strings.ToLower(name)]:
+				issues = append(issues, fmt.Sprintf("zone name %q is used more than once", name))
+			}
+			seen[strings.ToLower(name)] = true
+		}
+
+		return issues, nil
+	},
+}
+
+// SchedulesApplied checks that every zone has a schedule. With a baseline,
+// the schedules must match the ones of the baseline.
+var SchedulesApplied = Check{
+	Name: "schedules applied",
+	Run: func(home *Home) ([]string, error) {
+		if home.Baseline != nil {
+			return deviations(home, func(d config.Deviation) bool {
+				return d.Setting == "zone" || d.Setting == "timetable" || d.Setting == "away setting" || strings.HasPrefix(d.Setting, "schedule")
+			}), nil
+		}
+
+		var issues []string
+		for _, zone := range home.Config.Zones {
+			if zone.Schedule == nil || len(zone.Schedule.Blocks) == 0 {
+				issues = append(issues, fmt.Sprintf("zone %q has no schedule", zone.Name))
+			}
+		}
+
+		return issues, nil
+	},
+}
+
+// OffsetsSet checks that the temperature offsets of the devices match the
+// ones of the baseline. It is skipped without a baseline.
+var OffsetsSet = Check{
+	Name: "offsets set",
+	Run: func(home *Home) ([]string, error) {
+		if home.Baseline == nil {
+			return nil, ErrSkip
+		}
+
+		return deviations(home, func(d config.Deviation) bool {
+			return strings.HasPrefix(d.Setting, "temperature offset")
+		}), nil
+	},
+}
+
+// PresenceConfigured checks that the presence of the home is detected, i.e.
+// that a mobile device has geofencing enabled, or else that the presence is
+// locked.
+var PresenceConfigured = Check{
+	Name: "presence configured",
+	Run: func(home *Home) ([]string, error) {
+		for _, device := range home.MobileDevices {
+			if device.Settings.GeoTrackingEnabled {
+				return nil, nil
+			}
+		}
+
+		if home.State.PresenceLocked {
+			return nil, nil
+		}
+
+		return []string{"no mobile device has geofencing enabled and the presence is not locked"}, nil
+	},
+}
+
+// deviations returns the deviations of the home from its baseline that match
+// filter, as issues.
+func deviations(home *Home, filter func(config.Deviation) bool) []string {
+	var issues []string
+	for _, d := range config.Compare(home.Baseline, home.Config) {
+		if filter(d) {
+			issues = append(issues, d.String())
+		}
+	}
+
+	return issues
+}
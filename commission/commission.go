@@ -0,0 +1,231 @@
+// Package commission runs a commissioning checklist against a Tado home, e.g.
+// for heating installers handing over a provisioned home, and produces a
+// signed report of the results.
+package commission
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/idriesalbender/go-tado/config"
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// ErrSkip is returned by a Check that does not apply to a home, e.g. because
+// it needs a baseline and none was given.
+var ErrSkip = errors.New("check skipped")
+
+// ErrInvalidSignature is returned by Report.Verify if the report was not
+// signed with the key, or was modified after signing.
+var ErrInvalidSignature = errors.New("invalid report signature")
+
+// Home is the data of a home the checks of a checklist run against. It is
+// fetched once by Run.
+type Home struct {
+	Home          *tado.Home
+	State         *tado.State
+	Zones         []tado.Zone
+	Devices       []tado.Device
+	MobileDevices []tado.MobileDevice
+
+	// Config is the exported configuration of the home, see config.Export.
+	Config *config.HomeConfig
+
+	// Baseline is the configuration the home is expected to have, see
+	// Options.Baseline. It is nil if none was given.
+	Baseline *config.HomeConfig
+}
+
+// Check is an item of a commissioning checklist. Run returns the issues found
+// in the home, which pass the check if there are none, or ErrSkip.
+type Check struct {
+	Name string
+	Run  func(home *Home) ([]string, error)
+}
+
+// Status is the status of a check.
+type Status string
+
+const (
+	StatusPassed  Status = "PASSED"
+	StatusFailed  Status = "FAILED"
+	StatusSkipped Status = "SKIPPED"
+)
+
+// Result is the result of a check.
+type Result struct {
+	Check  string   `json:"check"`
+	Status Status   `json:"status"`
+	Issues []string `json:"issues,omitempty"`
+}
+
+// Report is the report of a commissioning run. Signature is the Ed25519
+// signature of the report without it, see Sign and Verify.
+type Report struct {
+	HomeID    tado.HomeID `json:"homeId"`
+	HomeName  string      `json:"homeName"`
+	Installer string      `json:"installer,omitempty"`
+	Time      time.Time   `json:"time"`
+	Passed    bool        `json:"passed"`
+	Results   []Result    `json:"results"`
+	Signature []byte      `json:"signature,omitempty"`
+}
+
+// Options configures Run.
+type Options struct {
+	// Checklist is the checklist to run. It defaults to DefaultChecklist.
+	Checklist []Check
+
+	// Baseline is the configuration the home is expected to have, e.g. the
+	// exported configuration of a reference home. SchedulesApplied and
+	// OffsetsSet compare the home against it.
+	Baseline *config.HomeConfig
+
+	// Installer identifies the installer in the report.
+	Installer string
+
+	// SigningKey signs the report if set.
+	SigningKey ed25519.PrivateKey
+}
+
+// Run fetches the home with the given ID, runs the checklist of opts against
+// it and returns the report, signed if opts has a SigningKey. The report
+// passes if no check failed.
+func Run(ctx context.Context, client *tado.Client, homeID tado.HomeID, opts Options) (*Report, error) {
+	checklist := opts.Checklist
+	if checklist == nil {
+		checklist = DefaultChecklist
+	}
+
+	home, err := fetch(ctx, client, homeID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching home %d: %w", homeID, err)
+	}
+	home.Baseline = opts.Baseline
+
+	report := &Report{
+		HomeID:    homeID,
+		HomeName:  home.Home.Name,
+		Installer: opts.Installer,
+		Time:      time.Now(),
+		Passed:    true,
+		Results:   []Result{},
+	}
+
+	for _, check := range checklist {
+		result := Result{Check: check.Name, Status: StatusPassed}
+
+		issues, err := check.Run(home)
+		switch {
+		case errors.Is(err, ErrSkip):
+			result.Status = StatusSkipped
+		case err != nil:
+			return nil, fmt.Errorf("check %q: %w", check.Name, err)
+		case len(issues) > 0:
+			result.Status, result.Issues = StatusFailed, issues
+			report.Passed = false
+		}
+
+		report.Results = append(report.Results, result)
+	}
+
+	if opts.SigningKey != nil {
+		if err := report.Sign(opts.SigningKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// fetch returns the data of the home with the given ID.
+func fetch(ctx context.Context, client *tado.Client, homeID tado.HomeID) (*Home, error) {
+	home := &Home{}
+
+	var err error
+	if home.Home, err = client.Home.Get(ctx, homeID); err != nil {
+		return nil, err
+	}
+	if home.State, err = client.Home.GetState(ctx, homeID); err != nil {
+		return nil, err
+	}
+	if home.Zones, err = client.Zone.List(ctx, homeID); err != nil {
+		return nil, err
+	}
+	if home.Devices, err = client.Device.List(ctx, homeID); err != nil {
+		return nil, err
+	}
+
+	mobileDevices, err := client.MobileDevice.List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+	home.MobileDevices = *mobileDevices
+
+	if home.Config, err = config.Export(ctx, client, homeID); err != nil {
+		return nil, err
+	}
+
+	return home, nil
+}
+
+// Sign signs the report with the given key, replacing any previous signature.
+func (r *Report) Sign(key ed25519.PrivateKey) error {
+	payload, err := r.payload()
+	if err != nil {
+		return err
+	}
+
+	r.Signature = ed25519.Sign(key, payload)
+	return nil
+}
+
+// Verify checks that the report was signed with the private key of the given
+// public key and not modified since. It returns ErrInvalidSignature
+// otherwise.
+func (r *Report) Verify(key ed25519.PublicKey) error {
+	payload, err := r.payload()
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(key, payload, r.Signature) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// payload returns the signed bytes of the report, i.e. its JSON encoding
+// without the signature.
+func (r *Report) payload() ([]byte, error) {
+	unsigned := *r
+	unsigned.Signature = nil
+
+	return json.Marshal(&unsigned)
+}
+
+// String returns a human-readable summary of the report.
+func (r *Report) String() string {
+	var b strings.Builder
+
+	status := StatusPassed
+	if !r.Passed {
+		status = StatusFailed
+	}
+	fmt.Fprintf(&b, "Commissioning of home %d (%s): %s\n", r.HomeID, r.HomeName, status)
+
+	for _, result := range r.Results {
+		fmt.Fprintf(&b, "  [%s] %s\n", result.Status, result.Check)
+		for _, issue := range result.Issues {
+			fmt.Fprintf(&b, "    - %s\n", issue)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,134 @@
+// Package config exports the configuration of Tado homes, such as their
+// schedules, temperature offsets and enabled features, and compares it
+// against a baseline, e.g. for compliance checks across a managed fleet of
+// homes.
+package config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/idriesalbender/go-tado/schedule"
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// HomeConfig is the exported configuration of a home. Zones are identified by
+// name, so that the configuration of one home can serve as the baseline of
+// another.
+//
+// When used as a baseline, nil fields are not compared.
+type HomeConfig struct {
+	HomeID            tado.HomeID  `json:"homeId,omitempty"`
+	Name              string       `json:"name,omitempty"`
+	IncidentDetection *bool        `json:"incidentDetection,omitempty"`
+	Zones             []ZoneConfig `json:"zones"`
+}
+
+// ZoneConfig is the exported configuration of a zone. TemperatureOffsets holds
+// the offset in degrees Celsius of every device of the zone that measures the
+// temperature.
+type ZoneConfig struct {
+	Name                string                        `json:"name"`
+	Type                tado.ZoneType                 `json:"type"`
+	Schedule            *schedule.Timetable           `json:"schedule,omitempty"`
+	EarlyStart          *bool                         `json:"earlyStart,omitempty"`
+	OpenWindowDetection *bool                         `json:"openWindowDetection,omitempty"`
+	DefaultOverlay      *tado.Termination             `json:"defaultOverlay,omitempty"`
+	TemperatureOffsets  map[tado.DeviceSerial]float64 `json:"temperatureOffsets,omitempty"`
+}
+
+// Zone returns the configuration of the zone with the given name, if any.
+func (c *HomeConfig) Zone(name string) (*ZoneConfig, bool) {
+	i := slices.IndexFunc(c.Zones, func(zone ZoneConfig) bool { return zone.Name == name })
+	if i < 0 {
+		return nil, false
+	}
+
+	return &c.Zones[i], true
+}
+
+// capabilityInsideTemperature is the capability of devices that measure the
+// temperature, and thus have a temperature offset.
+const capabilityInsideTemperature = "INSIDE_TEMPERATURE_MEASUREMENT"
+
+// Export returns the configuration of the home with the given ID.
+//
+// If the configuration of some zones cannot be retrieved, the configuration
+// of the home is returned without them, together with a *tado.MultiError.
+func Export(ctx context.Context, client *tado.Client, homeID tado.HomeID) (*HomeConfig, error) {
+	home, err := client.Home.Get(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := client.Zone.List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &HomeConfig{HomeID: homeID, Name: home.Name, Zones: []ZoneConfig{}}
+	if home.IncidentDetection.Supported {
+		config.IncidentDetection = &home.IncidentDetection.Enabled
+	}
+
+	errs := &tado.MultiError{}
+	for _, zone := range zones {
+		zoneConfig, err := exportZone(ctx, client, homeID, zone)
+		if err != nil {
+			errs.Add(fmt.Sprintf("zone %d", zone.ID), err)
+			continue
+		}
+
+		config.Zones = append(config.Zones, *zoneConfig)
+	}
+
+	return config, errs.ErrorOrNil()
+}
+
+// exportZone returns the configuration of the given zone.
+func exportZone(ctx context.Context, client *tado.Client, homeID tado.HomeID, zone tado.Zone) (*ZoneConfig, error) {
+	config := &ZoneConfig{Name: zone.Name, Type: zone.Type}
+
+	timetable, err := schedule.Fetch(ctx, client, homeID, zone.ID)
+	if err != nil {
+		return nil, err
+	}
+	config.Schedule = timetable
+
+	if zone.Type == tado.ZoneTypeHeating {
+		earlyStart, err := client.Zone.GetEarlyStart(ctx, homeID, zone.ID)
+		if err != nil {
+			return nil, err
+		}
+		config.EarlyStart = &earlyStart.Enabled
+	}
+
+	if zone.OpenWindowDetection.Supported {
+		config.OpenWindowDetection = &zone.OpenWindowDetection.Enabled
+	}
+
+	defaultOverlay, err := client.Zone.GetDefaultOverlay(ctx, homeID, zone.ID)
+	if err != nil {
+		return nil, err
+	}
+	config.DefaultOverlay = &defaultOverlay.TerminationCondition
+
+	for _, device := range zone.Devices {
+		if !slices.Contains(device.Characteristics.Capabilities, capabilityInsideTemperature) {
+			continue
+		}
+
+		offset, err := client.Device.GetTemperatureOffset(ctx, device.SerialNo)
+		if err != nil {
+			return nil, err
+		}
+
+		if config.TemperatureOffsets == nil {
+			config.TemperatureOffsets = map[tado.DeviceSerial]float64{}
+		}
+		config.TemperatureOffsets[device.SerialNo] = offset.Celsius
+	}
+
+	return config, nil
+}
@@ -0,0 +1,210 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/idriesalbender/go-tado/schedule"
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// Deviation is a difference between the configuration of a home and a
+// baseline. Zone is empty for settings of the home itself.
+type Deviation struct {
+	HomeID   tado.HomeID `json:"homeId"`
+	Zone     string      `json:"zone,omitempty"`
+	Setting  string      `json:"setting"`
+	Baseline string      `json:"baseline"`
+	Actual   string      `json:"actual"`
+}
+
+func (d Deviation) String() string {
+	target := fmt.Sprintf("home %d", d.HomeID)
+	if d.Zone != "" {
+		target += fmt.Sprintf(" zone %q", d.Zone)
+	}
+
+	return fmt.Sprintf("%s: %s is %s, expected %s", target, d.Setting, d.Actual, d.Baseline)
+}
+
+// Drift exports the configuration of the homes with the given IDs and returns
+// their deviations from the baseline, see Compare.
+//
+// If the configuration of some homes cannot be retrieved, the deviations of
+// the other homes are returned together with a *tado.MultiError.
+func Drift(ctx context.Context, client *tado.Client, homeIDs []tado.HomeID, baseline *HomeConfig) ([]Deviation, error) {
+	var deviations []Deviation
+
+	errs := &tado.MultiError{}
+	for _, homeID := range homeIDs {
+		config, err := Export(ctx, client, homeID)
+		if config == nil {
+			errs.Add(fmt.Sprintf("home %d", homeID), err)
+			continue
+		}
+		if err != nil {
+			errs.Add(fmt.Sprintf("home %d", homeID), err)
+		}
+
+		deviations = append(deviations, Compare(baseline, config)...)
+	}
+
+	return deviations, errs.ErrorOrNil()
+}
+
+// Compare returns the deviations of the configuration config from the
+// baseline. Zones are matched by name; zones missing from config are
+// reported, while zones missing from the baseline are not compared. Fields
+// that are nil in the baseline are not compared.
+//
+// Devices that are not in the TemperatureOffsets of the baseline zone are
+// expected to have no offset.
+func Compare(baseline, config *HomeConfig) []Deviation {
+	var deviations []Deviation
+	add := func(zone, setting, expected, actual string) {
+		if expected != actual {
+			deviations = append(deviations, Deviation{
+				HomeID:   config.HomeID,
+				Zone:     zone,
+				Setting:  setting,
+				Baseline: expected,
+				Actual:   actual,
+			})
+		}
+	}
+
+	if baseline.IncidentDetection != nil {
+		add("", "incident detection", formatEnabled(baseline.IncidentDetection), formatEnabled(config.IncidentDetection))
+	}
+
+	for _, expected := range baseline.Zones {
+		actual, ok := config.Zone(expected.Name)
+		if !ok {
+			add(expected.Name, "zone", "present", "missing")
+			continue
+		}
+
+		if expected.Schedule != nil {
+			compareSchedule(expected.Schedule, actual.Schedule, func(setting, e, a string) {
+				add(expected.Name, setting, e, a)
+			})
+		}
+
+		if expected.EarlyStart != nil {
+			add(expected.Name, "early start", formatEnabled(expected.EarlyStart), formatEnabled(actual.EarlyStart))
+		}
+
+		if expected.OpenWindowDetection != nil {
+			add(expected.Name, "open window detection", formatEnabled(expected.OpenWindowDetection), formatEnabled(actual.OpenWindowDetection))
+		}
+
+		if expected.DefaultOverlay != nil {
+			add(expected.Name, "default overlay", formatTermination(expected.DefaultOverlay), formatTermination(actual.DefaultOverlay))
+		}
+
+		if expected.TemperatureOffsets != nil {
+			for serialNo, offset := range actual.TemperatureOffsets {
+				want := expected.TemperatureOffsets[serialNo]
+				if math.Abs(offset-want) >= 0.05 {
+					add(expected.Name, fmt.Sprintf("temperature offset of %s", serialNo), formatOffset(want), formatOffset(offset))
+				}
+			}
+		}
+	}
+
+	return deviations
+}
+
+// compareSchedule reports the differences between the timetables expected
+// and actual to add.
+func compareSchedule(expected, actual *schedule.Timetable, add func(setting, expected, actual string)) {
+	if actual == nil {
+		add("schedule", "present", "missing")
+		return
+	}
+
+	if expected.Type.ID != actual.Type.ID {
+		add("timetable", expected.Type.Type, actual.Type.Type)
+		return
+	}
+
+	for _, dayType := range expected.Type.DayTypes() {
+		add(fmt.Sprintf("schedule %s", dayType), formatBlocks(expected.BlocksOf(dayType)), formatBlocks(actual.BlocksOf(dayType)))
+	}
+
+	if expected.Away != nil {
+		add("away setting", formatAway(expected.Away), formatAway(actual.Away))
+	}
+}
+
+// formatEnabled formats an optional boolean setting.
+func formatEnabled(enabled *bool) string {
+	switch {
+	case enabled == nil:
+		return "unsupported"
+	case *enabled:
+		return "enabled"
+	default:
+		return "disabled"
+	}
+}
+
+// formatTermination formats an optional termination.
+func formatTermination(t *tado.Termination) string {
+	switch {
+	case t == nil:
+		return "unset"
+	case t.Type == tado.TerminationTimer:
+		return fmt.Sprintf("%s %ds", t.Type, t.DurationInSeconds)
+	default:
+		return string(t.Type)
+	}
+}
+
+// formatOffset formats a temperature offset in degrees Celsius.
+func formatOffset(celsius float64) string {
+	return fmt.Sprintf("%+.1f°C", celsius)
+}
+
+// formatSetting formats the setting of a block or the away configuration.
+func formatSetting(setting tado.ZoneSetting) string {
+	if setting.Power != tado.PowerOn {
+		return "off"
+	}
+
+	if setting.Temperature == nil {
+		return "on"
+	}
+
+	return setting.Temperature.Format(tado.UnitCelsius)
+}
+
+// formatBlocks formats the blocks of a day type, e.g. "07:00-22:00 21.0°C".
+func formatBlocks(blocks []tado.ScheduleBlock) string {
+	if len(blocks) == 0 {
+		return "none"
+	}
+
+	parts := make([]string, len(blocks))
+	for i, block := range blocks {
+		parts[i] = fmt.Sprintf("%s-%s %s", block.Start, block.End, formatSetting(block.Setting))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// formatAway formats an optional away configuration.
+func formatAway(away *tado.AwayConfiguration) string {
+	switch {
+	case away == nil:
+		return "unset"
+	case away.AutoAdjust:
+		return fmt.Sprintf("auto-adjust comfort level %d", away.ComfortLevel)
+	case away.Setting == nil:
+		return "unset"
+	default:
+		return formatSetting(*away.Setting)
+	}
+}
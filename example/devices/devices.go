@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// load the home ID and token file from the TADO_* environment variables
+	config, err := tado.LoadConfig("")
+	if err != nil {
+		panic(err)
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		panic(err)
+	}
+
+	// create a new tado client
+	client := tado.NewClient(opts...)
+
+	// list the devices that need a battery change or maintenance
+	for device, err := range client.Device.All(ctx, config.HomeID) {
+		if err != nil {
+			panic(err)
+		}
+
+		switch {
+		case !device.IsOnline():
+			fmt.Printf("%s (%s) is offline\n", device.SerialNo, device.DeviceType)
+		case device.BatteryState == tado.BatteryStateLow:
+			fmt.Printf("%s (%s) has a low battery\n", device.SerialNo, device.DeviceType)
+		case device.NeedsAttention():
+			fmt.Printf("%s (%s) needs to be remounted or calibrated\n", device.SerialNo, device.DeviceType)
+		}
+	}
+}
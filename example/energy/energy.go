@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// load the home ID and token file from the TADO_* environment variables
+	config, err := tado.LoadConfig("")
+	if err != nil {
+		panic(err)
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		panic(err)
+	}
+
+	// create a new tado client
+	client := tado.NewClient(opts...)
+
+	// read the consumption of last month
+	month := time.Now().AddDate(0, -1, 0)
+	consumption, err := client.EnergyIQ.GetConsumption(ctx, config.HomeID, month)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("consumed %.1f %s for %.2f %s\n", consumption.Summary.Consumption, consumption.Unit, consumption.Summary.CostInCents/100, consumption.Currency)
+
+	// attribute the boiler running time to the zones, assuming equal heating
+	// power
+	attribution, err := client.EnergyIQ.ZoneAttribution(ctx, config.HomeID, month, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, zone := range attribution {
+		fmt.Printf("%s: %.0f%% (%s)\n", zone.ZoneName, zone.Share*100, zone.RunningTime)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// load the home ID and token file from the TADO_* environment variables
+	config, err := tado.LoadConfig("")
+	if err != nil {
+		panic(err)
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		panic(err)
+	}
+
+	// create a new tado client
+	client := tado.NewClient(opts...)
+
+	home, err := client.Home.Get(ctx, config.HomeID)
+	if err != nil {
+		panic(err)
+	}
+
+	state, err := client.Home.GetState(ctx, config.HomeID)
+	if err != nil {
+		panic(err)
+	}
+
+	weather, err := client.Home.GetWeather(ctx, config.HomeID)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%s is %s, it is %.1f°C outside\n", home.Name, state.Presence, weather.OutsideTemperature.Celsius)
+
+	// list who is at home
+	for device, err := range client.MobileDevice.All(ctx, config.HomeID) {
+		if err != nil {
+			panic(err)
+		}
+		if device.Location.AtHome {
+			fmt.Printf("%s is at home\n", device.Name)
+		}
+	}
+}
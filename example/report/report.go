@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/idriesalbender/go-tado/analysis"
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// load the home ID and token file from the TADO_* environment variables
+	config, err := tado.LoadConfig("")
+	if err != nil {
+		panic(err)
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		panic(err)
+	}
+
+	// create a new tado client
+	client := tado.NewClient(opts...)
+
+	zones, err := client.Zone.List(ctx, config.HomeID)
+	if err != nil {
+		panic(err)
+	}
+
+	// read the day reports of the last week for every heating zone
+	to := time.Now().AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -6)
+	for _, zone := range zones {
+		if zone.Type != tado.ZoneTypeHeating {
+			continue
+		}
+
+		var rates analysis.Rates
+		for report, err := range client.Zone.DayReports(ctx, config.HomeID, zone.ID, from, to) {
+			if err != nil {
+				panic(err)
+			}
+			rates = analysis.Merge(rates, analysis.RatesOfDayReport(report))
+		}
+
+		fmt.Printf("%s heats at %.2f°C/h and cools at %.2f°C/h\n", zone.Name, rates.Heating, rates.Cooling)
+	}
+
+	// read the outside temperatures of the same week
+	history, err := client.Report.OutsideTemperatureHistory(ctx, config.HomeID, from, to)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, point := range history {
+		fmt.Printf("%s %.1f°C\n", point.Timestamp.Format(time.DateTime), point.Value.Celsius)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// load the home ID and token file from the TADO_* environment variables
+	config, err := tado.LoadConfig("")
+	if err != nil {
+		panic(err)
+	}
+
+	opts, err := config.Options()
+	if err != nil {
+		panic(err)
+	}
+
+	// create a new tado client
+	client := tado.NewClient(opts...)
+
+	// find the first heating zone of the home
+	var zone *tado.Zone
+	for z, err := range client.Zone.All(ctx, config.HomeID) {
+		if err != nil {
+			panic(err)
+		}
+		if z.Type == tado.ZoneTypeHeating {
+			zone = &z
+			break
+		}
+	}
+	if zone == nil {
+		panic("no heating zone")
+	}
+
+	// heat the zone to 21 degrees for 30 minutes, verifying that it was applied
+	overlay, err := client.Zone.SetTemperature(ctx, config.HomeID, zone.ID, 21, tado.TimerTermination(30*time.Minute), tado.WithVerify())
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%s is heating to %.1f°C\n", zone.Name, overlay.Setting.Temperature.Celsius)
+
+	// return to the schedule
+	if err := client.Zone.DeleteOverlay(ctx, config.HomeID, zone.ID); err != nil {
+		panic(err)
+	}
+}
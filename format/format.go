@@ -0,0 +1,104 @@
+// Package format renders Tado models as concise human-readable text, e.g. for
+// chatbots posting to Slack or Telegram.
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// Summary is the JSON variant of HomeSummary. Temperatures are in Unit.
+type Summary struct {
+	Home               string               `json:"home"`
+	Unit               tado.TemperatureUnit `json:"unit"`
+	Presence           tado.Presence        `json:"presence,omitempty"`
+	OutsideTemperature *float64             `json:"outsideTemperature,omitempty"`
+	Weather            string               `json:"weather,omitempty"`
+	Rooms              []RoomSummary        `json:"rooms"`
+}
+
+// RoomSummary is the summary of a single room.
+type RoomSummary struct {
+	Name        string   `json:"name"`
+	Temperature float64  `json:"temperature"`
+	Target      *float64 `json:"target"`
+	Humidity    float64  `json:"humidity"`
+}
+
+// NewSummary builds a Summary from the given snapshot.
+func NewSummary(s *tado.HomeSnapshot) *Summary {
+	summary := &Summary{Unit: s.Unit, Rooms: []RoomSummary{}}
+	if summary.Unit == "" {
+		summary.Unit = tado.UnitCelsius
+	}
+
+	if s.Home != nil {
+		summary.Home = s.Home.Name
+	}
+
+	if s.State != nil {
+		summary.Presence = s.State.Presence
+	}
+
+	if s.Weather != nil {
+		outside := s.Weather.OutsideTemperature.In(summary.Unit)
+		summary.OutsideTemperature = &outside
+		summary.Weather = s.Weather.WeatherState.Value
+	}
+
+	for _, room := range s.Rooms {
+		summary.Rooms = append(summary.Rooms, RoomSummary{
+			Name:        room.Name,
+			Temperature: room.Temperature,
+			Target:      room.Target,
+			Humidity:    room.Humidity,
+		})
+	}
+
+	return summary
+}
+
+// HomeSummary returns a concise multi-line summary of the given snapshot in
+// the unit of the snapshot, e.g.:
+//
+//	My Home (HOME), outside 4.2°C, CLOUDY
+//	Living room: 20.5°C → 21.0°C, 45% humidity
+//	Bedroom: 18.1°C (off), 52% humidity
+func HomeSummary(s *tado.HomeSnapshot) string {
+	summary := NewSummary(s)
+
+	symbol := summary.Unit.Symbol()
+
+	var b strings.Builder
+
+	b.WriteString(summary.Home)
+	if summary.Presence != "" {
+		fmt.Fprintf(&b, " (%s)", summary.Presence)
+	}
+	if summary.OutsideTemperature != nil {
+		fmt.Fprintf(&b, ", outside %.1f%s", *summary.OutsideTemperature, symbol)
+	}
+	if summary.Weather != "" {
+		fmt.Fprintf(&b, ", %s", summary.Weather)
+	}
+
+	for _, room := range summary.Rooms {
+		fmt.Fprintf(&b, "\n%s: %.1f%s", room.Name, room.Temperature, symbol)
+		if room.Target != nil {
+			fmt.Fprintf(&b, " → %.1f%s", *room.Target, symbol)
+		} else {
+			b.WriteString(" (off)")
+		}
+		fmt.Fprintf(&b, ", %.0f%% humidity", room.Humidity)
+	}
+
+	return b.String()
+}
+
+// HomeSummaryJSON returns the summary of the given snapshot as JSON.
+func HomeSummaryJSON(s *tado.HomeSnapshot) ([]byte, error) {
+	return json.Marshal(NewSummary(s))
+}
@@ -2,4 +2,8 @@ module github.com/idriesalbender/go-tado
 
 go 1.23.5
 
-require golang.org/x/oauth2 v0.25.0
+require (
+	golang.org/x/oauth2 v0.25.0
+	golang.org/x/time v0.9.0
+	gopkg.in/yaml.v3 v3.0.1
+)
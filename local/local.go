@@ -0,0 +1,102 @@
+// Package local provides an integration point for discovering Tado X devices
+// on the local network (via Thread/Matter) and correlating them with the
+// devices known to the Tado API, so hybrid local/cloud controllers can be
+// built on this library.
+//
+// This package does not implement a discovery protocol itself; implementations
+// based on mDNS/DNS-SD or a Matter controller plug in through the Discoverer
+// interface.
+package local
+
+import (
+	"context"
+	"strings"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// DiscoveredDevice is a device found on the local network.
+type DiscoveredDevice struct {
+	// SerialNo is the serial number advertised by the device, if any.
+	SerialNo string
+
+	// Name is the advertised instance name of the device.
+	Name string
+
+	// Addr is the address (host:port) the device can be reached at.
+	Addr string
+
+	// Protocol is the protocol the device was discovered with, e.g.
+	// "matter" or "thread".
+	Protocol string
+
+	// TXT holds the advertised DNS-SD TXT records, if any.
+	TXT map[string]string
+}
+
+// Discoverer discovers Tado devices on the local network.
+type Discoverer interface {
+	Discover(ctx context.Context) ([]DiscoveredDevice, error)
+}
+
+// DiscovererFunc is an adapter to allow the use of ordinary functions as
+// Discoverers.
+type DiscovererFunc func(ctx context.Context) ([]DiscoveredDevice, error)
+
+// Discover implements the Discoverer interface.
+func (f DiscovererFunc) Discover(ctx context.Context) ([]DiscoveredDevice, error) {
+	return f(ctx)
+}
+
+// Static is a Discoverer returning a fixed list of devices, e.g. from
+// configuration.
+type Static []DiscoveredDevice
+
+// Discover implements the Discoverer interface.
+func (s Static) Discover(context.Context) ([]DiscoveredDevice, error) {
+	return s, nil
+}
+
+// Match is a local device matched to a device known to the Tado API.
+type Match struct {
+	Local  DiscoveredDevice
+	Device tado.Device
+}
+
+// Correlate matches the discovered devices with the given API devices by
+// (short) serial number. It returns the matches and the discovered devices
+// that could not be matched.
+func Correlate(discovered []DiscoveredDevice, devices []tado.Device) ([]Match, []DiscoveredDevice) {
+	bySerial := make(map[string]tado.Device, len(devices)*2)
+	for _, device := range devices {
+		bySerial[strings.ToUpper(string(device.SerialNo))] = device
+		if device.ShortSerialNo != "" {
+			bySerial[strings.ToUpper(device.ShortSerialNo)] = device
+		}
+	}
+
+	var matches []Match
+	var unmatched []DiscoveredDevice
+	for _, d := range discovered {
+		serial := serialOf(d)
+		device, ok := bySerial[strings.ToUpper(serial)]
+		if serial == "" || !ok {
+			unmatched = append(unmatched, d)
+			continue
+		}
+
+		matches = append(matches, Match{Local: d, Device: device})
+	}
+
+	return matches, unmatched
+}
+
+// serialOf returns the serial number of a discovered device, falling back to
+// the "SN" TXT record.
+func serialOf(d DiscoveredDevice) string {
+	if d.SerialNo != "" {
+		return d.SerialNo
+	}
+
+	return d.TXT["SN"]
+}
@@ -0,0 +1,251 @@
+// Package notify forwards Tado events to messaging services such as Slack and
+// Telegram.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// Message is a notification to be delivered by a Notifier.
+type Message struct {
+	Title string
+	Text  string
+}
+
+// String returns the message as plain text.
+func (m Message) String() string {
+	if m.Title == "" {
+		return m.Text
+	}
+
+	return m.Title + "\n" + m.Text
+}
+
+// Notifier delivers messages to a messaging service.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// NotifierFunc is an adapter to allow the use of ordinary functions as
+// Notifiers.
+type NotifierFunc func(ctx context.Context, msg Message) error
+
+// Notify implements the Notifier interface.
+func (f NotifierFunc) Notify(ctx context.Context, msg Message) error {
+	return f(ctx, msg)
+}
+
+// Forward reads events from the given channel until it is closed or ctx is
+// done, converts them to messages using format and delivers them using n.
+// Events for which format returns false are skipped. Delivery errors are
+// passed to onError, if not nil.
+func Forward[E any](ctx context.Context, events <-chan E, n Notifier, format func(E) (Message, bool), onError func(error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			msg, ok := format(e)
+			if !ok {
+				continue
+			}
+
+			if err := n.Notify(ctx, msg); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
+
+// IncidentMessage formats an incident event, for use with Forward.
+func IncidentMessage(e tado.IncidentEvent) (Message, bool) {
+	switch e.Type {
+	case tado.IncidentOpened:
+		return Message{
+			Title: fmt.Sprintf("Tado incident in home %d", e.HomeID),
+			Text:  fmt.Sprintf("%s incident opened at %s", e.Incident.Type, e.Incident.CreatedAt.Format("15:04")),
+		}, true
+	case tado.IncidentResolved:
+		return Message{
+			Title: fmt.Sprintf("Tado incident in home %d", e.HomeID),
+			Text:  fmt.Sprintf("%s incident resolved", e.Incident.Type),
+		}, true
+	default:
+		return Message{}, false
+	}
+}
+
+// WatchMessage formats a watch event, for use with Forward. Temperature
+// changes are skipped, as they are too frequent to be notified about.
+func WatchMessage(e tado.WatchEvent) (Message, bool) {
+	title := fmt.Sprintf("Tado home %d", e.HomeID)
+
+	switch e.Type {
+	case tado.PresenceChanged:
+		return Message{Title: title, Text: fmt.Sprintf("Presence changed to %s", e.Presence)}, true
+	case tado.OverlaySet, tado.OverlayCleared:
+		return OverlayMessage(e)
+	case tado.DeviceOffline:
+		return Message{Title: title, Text: fmt.Sprintf("Device %s went offline", e.Device.SerialNo)}, true
+	case tado.DeviceOnline:
+		return Message{Title: title, Text: fmt.Sprintf("Device %s is back online", e.Device.SerialNo)}, true
+	case tado.OutsideTemperatureDropping:
+		return Message{
+			Title: title,
+			Text:  fmt.Sprintf("Outside temperature dropping at %.1f °C/h, now %.1f °C", e.Rate, e.Temperature.Celsius),
+		}, true
+	case tado.WatchFailed:
+		return Message{Title: title, Text: fmt.Sprintf("Watching failed: %v", e.Err)}, true
+	default:
+		return Message{}, false
+	}
+}
+
+// OverlayMessage formats the overlay events of a watch, for use with Forward.
+// Other events are skipped.
+func OverlayMessage(e tado.WatchEvent) (Message, bool) {
+	title := fmt.Sprintf("Tado zone %d in home %d", e.ZoneID, e.HomeID)
+
+	switch e.Type {
+	case tado.OverlaySet:
+		return Message{Title: title, Text: fmt.Sprintf("Manual control set: %s", describeOverlay(e.Overlay))}, true
+	case tado.OverlayCleared:
+		return Message{Title: title, Text: "Manual control ended, back on schedule"}, true
+	default:
+		return Message{}, false
+	}
+}
+
+// describeOverlay returns a short description of the setting and termination
+// of an overlay.
+func describeOverlay(overlay *tado.Overlay) string {
+	setting := "off"
+	if overlay.Setting.Power == tado.PowerOn {
+		setting = "on"
+		if overlay.Setting.Temperature != nil {
+			setting = fmt.Sprintf("%.1f °C", overlay.Setting.Temperature.Celsius)
+		}
+	}
+
+	switch overlay.Termination.Type {
+	case tado.TerminationManual:
+		return setting + " until changed"
+	case tado.TerminationTimer:
+		return fmt.Sprintf("%s for %s", setting, time.Duration(overlay.Termination.DurationInSeconds)*time.Second)
+	case tado.TerminationTadoMode:
+		return setting + " until the presence changes"
+	default:
+		return setting
+	}
+}
+
+// RemindOverlays reads watch events from the given channel until it is closed
+// or ctx is done, and notifies n once when a zone has been under manual
+// control for the given duration, e.g. to be messaged when the heating has
+// been on manual for 3 hours. Overlays that were already set when the watch
+// started are not seen, as the first poll of a watch delivers no events.
+// Delivery errors are passed to onError, if not nil.
+func RemindOverlays(ctx context.Context, events <-chan tado.WatchEvent, n Notifier, after time.Duration, onError func(error)) {
+	type zoneKey struct {
+		homeID tado.HomeID
+		zoneID tado.ZoneID
+	}
+
+	timers := map[zoneKey]*time.Timer{}
+	due := make(chan zoneKey)
+	done := make(chan struct{})
+	defer func() {
+		close(done)
+		for _, t := range timers {
+			t.Stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case key := <-due:
+			delete(timers, key)
+			msg := Message{
+				Title: fmt.Sprintf("Tado zone %d in home %d", key.zoneID, key.homeID),
+				Text:  fmt.Sprintf("Manual control has been active for %s", after),
+			}
+			if err := n.Notify(ctx, msg); err != nil && onError != nil {
+				onError(err)
+			}
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			key := zoneKey{e.HomeID, e.ZoneID}
+			switch e.Type {
+			case tado.OverlaySet:
+				if _, ok := timers[key]; !ok {
+					timers[key] = time.AfterFunc(after, func() {
+						select {
+						case due <- key:
+						case <-done:
+						}
+					})
+				}
+			case tado.OverlayCleared:
+				if t, ok := timers[key]; ok {
+					t.Stop()
+					delete(timers, key)
+				}
+			}
+		}
+	}
+}
+
+// postJSON posts v as JSON to endpoint using client.
+func postJSON(ctx context.Context, client *http.Client, endpoint string, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		// *url.Error holds the full URL, so it is unwrapped and only the host
+		// is reported, as the path may contain a secret token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("POST %s: %w", req.URL.Host, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		// only the host is reported, as the path may contain a secret token
+		return fmt.Errorf("POST %s: unexpected status %s", req.URL.Host, res.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+)
+
+// Slack delivers messages to a Slack incoming webhook.
+type Slack struct {
+	WebhookURL string
+	HTTPClient *http.Client
+}
+
+// Notify implements the Notifier interface.
+func (s *Slack) Notify(ctx context.Context, msg Message) error {
+	text := msg.Text
+	if msg.Title != "" {
+		text = "*" + msg.Title + "*\n" + msg.Text
+	}
+
+	return postJSON(ctx, s.HTTPClient, s.WebhookURL, map[string]string{"text": text})
+}
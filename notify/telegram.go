@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// DefaultTelegramBaseURL is the base URL of the Telegram Bot API.
+const DefaultTelegramBaseURL = "https://api.telegram.org"
+
+// Telegram delivers messages to a Telegram chat using the Bot API.
+type Telegram struct {
+	Token      string
+	ChatID     string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// Notify implements the Notifier interface.
+func (t *Telegram) Notify(ctx context.Context, msg Message) error {
+	baseURL := t.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultTelegramBaseURL
+	}
+
+	return postJSON(ctx, t.HTTPClient, fmt.Sprintf("%s/bot%s/sendMessage", baseURL, t.Token), map[string]string{
+		"chat_id": t.ChatID,
+		"text":    msg.String(),
+	})
+}
@@ -0,0 +1,140 @@
+// Package preheat plans staggered preheat start times for multiple zones of a
+// home, so that all zones reach their target temperature in time without all
+// of them calling for heat at the same moment.
+package preheat
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/idriesalbender/go-tado/analysis"
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// Target is the temperature a zone must reach at a given time.
+type Target struct {
+	ZoneID      tado.ZoneID
+	At          time.Time
+	Current     float64
+	Temperature float64
+}
+
+// Start is a planned preheat start for a zone. The zone must be heated to
+// Temperature from At until Until.
+type Start struct {
+	ZoneID      tado.ZoneID
+	At          time.Time
+	Until       time.Time
+	Temperature float64
+}
+
+// Duration returns the duration of the preheat.
+func (s Start) Duration() time.Duration {
+	return s.Until.Sub(s.At)
+}
+
+// Options configures the planner.
+type Options struct {
+	// MinGap is the minimum time between two consecutive preheat starts.
+	MinGap time.Duration
+
+	// Margin is added to every estimated preheat duration.
+	Margin time.Duration
+
+	// Fallback is the preheat duration used for zones without a usable
+	// heating rate in the thermal model.
+	Fallback time.Duration
+}
+
+// Plan computes the preheat starts for the given targets using the heating
+// rates of the thermal model. Starts are only ever moved earlier to honour
+// MinGap, so that every zone still reaches its target in time. Zones that are
+// already at or above their target need no preheat and are skipped. A nil
+// model uses Fallback for every zone. The returned starts are sorted by start
+// time.
+func Plan(model *analysis.ThermalModel, targets []Target, opts Options) ([]Start, error) {
+	starts := make([]Start, 0, len(targets))
+
+	for _, target := range targets {
+		if target.Current >= target.Temperature {
+			continue
+		}
+
+		d := opts.Fallback
+		if model != nil {
+			if room, ok := model.Room(target.ZoneID); ok {
+				if estimate, ok := room.Rates.PreheatDuration(target.Current, target.Temperature); ok {
+					d = estimate
+				}
+			}
+		}
+
+		if d <= 0 {
+			return nil, fmt.Errorf("no heating rate known for zone %d", target.ZoneID)
+		}
+
+		d += opts.Margin
+		starts = append(starts, Start{
+			ZoneID:      target.ZoneID,
+			At:          target.At.Add(-d),
+			Until:       target.At,
+			Temperature: target.Temperature,
+		})
+	}
+
+	// stagger from the latest start backwards
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].At.After(starts[j].At)
+	})
+	for i := 1; i < len(starts); i++ {
+		if latest := starts[i-1].At.Add(-opts.MinGap); starts[i].At.After(latest) {
+			starts[i].At = latest
+		}
+	}
+
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].At.Before(starts[j].At)
+	})
+
+	return starts, nil
+}
+
+// Apply waits for each planned start and calls apply for it, until all starts
+// are applied or ctx is done. Starts whose time has already passed are applied
+// immediately. The first error returned by apply aborts the plan.
+func Apply(ctx context.Context, starts []Start, apply func(context.Context, Start) error) error {
+	for _, start := range starts {
+		if d := time.Until(start.At); d > 0 {
+			timer := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		if err := apply(ctx, start); err != nil {
+			return fmt.Errorf("applying preheat for zone %d: %w", start.ZoneID, err)
+		}
+	}
+
+	return nil
+}
+
+// TimerOverlay returns an apply function for Apply that heats each zone of the
+// given home using a timer overlay lasting until the target time.
+func TimerOverlay(client *tado.Client, homeID tado.HomeID) func(context.Context, Start) error {
+	return func(ctx context.Context, start Start) error {
+		d := time.Until(start.Until)
+		if d <= 0 {
+			return nil
+		}
+
+		overlay := tado.NewOverlay(tado.HeatingSetting(start.Temperature), tado.TimerTermination(d))
+		_, err := client.Zone.SetOverlay(ctx, homeID, start.ZoneID, overlay)
+		return err
+	}
+}
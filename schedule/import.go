@@ -0,0 +1,394 @@
+package schedule
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// weekdays are the day types of a seven day timetable, Monday first.
+var weekdays = [7]tado.DayType{
+	tado.DayTypeMonday, tado.DayTypeTuesday, tado.DayTypeWednesday, tado.DayTypeThursday,
+	tado.DayTypeFriday, tado.DayTypeSaturday, tado.DayTypeSunday,
+}
+
+// ImportHomeAssistant imports a Home Assistant schedule helper from YAML, e.g.:
+//
+//	schedule:
+//	  heating:
+//	    name: Heating
+//	    monday:
+//	      - from: "06:30:00"
+//	        to: "22:00:00"
+//	        data:
+//	          temperature: 21
+//
+// The document may also hold a single schedule without the surrounding
+// schedule and ID keys. Every slot must have a temperature in its data, in the
+// given unit and must end after it starts, using "24:00:00" for midnight;
+// periods not covered by any slot are switched off. The returned
+// timetable uses the simplest timetable type that can represent the schedule.
+func ImportHomeAssistant(r io.Reader, unit tado.TemperatureUnit) (*Timetable, error) {
+	var file struct {
+		Schedule   map[string]haSchedule `yaml:"schedule"`
+		haSchedule `yaml:",inline"`
+	}
+	if err := yaml.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("decoding Home Assistant schedule: %w", err)
+	}
+
+	schedule := file.haSchedule
+	switch len(file.Schedule) {
+	case 0:
+	case 1:
+		for _, s := range file.Schedule {
+			schedule = s
+		}
+	default:
+		return nil, errors.New("Home Assistant file holds more than one schedule")
+	}
+
+	var w week
+	for i, slots := range schedule.days() {
+		for _, slot := range slots {
+			s, err := slot.segment(unit)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", strings.ToLower(string(weekdays[i])), err)
+			}
+			w[i] = append(w[i], s)
+		}
+	}
+
+	return w.timetable(), nil
+}
+
+// haSchedule is a Home Assistant schedule helper.
+type haSchedule struct {
+	Monday    []haSlot `yaml:"monday"`
+	Tuesday   []haSlot `yaml:"tuesday"`
+	Wednesday []haSlot `yaml:"wednesday"`
+	Thursday  []haSlot `yaml:"thursday"`
+	Friday    []haSlot `yaml:"friday"`
+	Saturday  []haSlot `yaml:"saturday"`
+	Sunday    []haSlot `yaml:"sunday"`
+}
+
+// days returns the slots of every day of the schedule, Monday first.
+func (s haSchedule) days() [7][]haSlot {
+	return [7][]haSlot{s.Monday, s.Tuesday, s.Wednesday, s.Thursday, s.Friday, s.Saturday, s.Sunday}
+}
+
+// haSlot is a time slot of a Home Assistant schedule helper.
+type haSlot struct {
+	From string         `yaml:"from"`
+	To   string         `yaml:"to"`
+	Data map[string]any `yaml:"data"`
+}
+
+// segment returns the segment of the slot, with its temperature in the given
+// unit.
+func (s haSlot) segment(unit tado.TemperatureUnit) (segment, error) {
+	start, err := parseClock(s.From)
+	if err != nil {
+		return segment{}, err
+	}
+
+	end, err := parseClock(s.To)
+	if err != nil {
+		return segment{}, err
+	}
+
+	if end <= start {
+		return segment{}, fmt.Errorf("slot %s-%s does not end after it starts, use 24:00:00 for midnight", s.From, s.To)
+	}
+
+	var value float64
+	switch v := s.Data["temperature"].(type) {
+	case int:
+		value = float64(v)
+	case float64:
+		value = v
+	default:
+		return segment{}, fmt.Errorf("slot %s-%s has no temperature", s.From, s.To)
+	}
+
+	return segment{start: start, end: end, setting: heatingSetting(value, unit)}, nil
+}
+
+// ImportNestCSV imports a Google Nest schedule exported as CSV with the
+// columns day, time and temperature, e.g.:
+//
+//	day,time,temperature
+//	Monday,06:30,21
+//	Monday,22:00,off
+//
+// The header is required; the order of the columns is free. Each row is a
+// setpoint with a temperature in the given unit, or "off", that holds until
+// the next setpoint of the week, wrapping around from Sunday to Monday. The
+// returned timetable uses the simplest timetable type that can represent the
+// schedule.
+func ImportNestCSV(r io.Reader, unit tado.TemperatureUnit) (*Timetable, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading Nest CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"day", "time", "temperature"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("Nest CSV has no %s column", name)
+		}
+	}
+
+	type setpoint struct {
+		at      int
+		setting tado.ZoneSetting
+	}
+
+	var setpoints []setpoint
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading Nest CSV: %w", err)
+		}
+
+		day, ok := parseWeekday(record[columns["day"]])
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", record[columns["day"]])
+		}
+
+		at, err := parseClock(record[columns["time"]])
+		if err != nil {
+			return nil, err
+		}
+
+		setting := tado.OffSetting(tado.ZoneTypeHeating)
+		if v := strings.TrimSpace(record[columns["temperature"]]); !strings.EqualFold(v, "off") {
+			value, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid temperature %q", v)
+			}
+			setting = heatingSetting(value, unit)
+		}
+
+		setpoints = append(setpoints, setpoint{at: day*minutesPerDay + at, setting: setting})
+	}
+
+	if len(setpoints) == 0 {
+		return nil, errors.New("Nest CSV has no setpoints")
+	}
+
+	slices.SortStableFunc(setpoints, func(a, b setpoint) int {
+		return a.at - b.at
+	})
+
+	var w week
+	last := setpoints[len(setpoints)-1]
+	w.add(0, setpoints[0].at, last.setting)
+	for i, p := range setpoints {
+		end := 7 * minutesPerDay
+		if i+1 < len(setpoints) {
+			end = setpoints[i+1].at
+		}
+		w.add(p.at, end, p.setting)
+	}
+
+	return w.timetable(), nil
+}
+
+// segment is a period of a day, in minutes since midnight, with its setting.
+type segment struct {
+	start, end int
+	setting    tado.ZoneSetting
+}
+
+// week holds the segments of every day of a week, Monday first.
+type week [7][]segment
+
+// add adds a segment spanning from start to end, in minutes since the start of
+// the week, splitting it at midnight.
+func (w *week) add(start, end int, setting tado.ZoneSetting) {
+	for start < end {
+		day := start / minutesPerDay
+		dayEnd := min(end, (day+1)*minutesPerDay)
+		w[day] = append(w[day], segment{
+			start:   start - day*minutesPerDay,
+			end:     dayEnd - day*minutesPerDay,
+			setting: setting,
+		})
+		start = dayEnd
+	}
+}
+
+// timetable returns the week as a timetable of the simplest type that can
+// represent it.
+func (w *week) timetable() *Timetable {
+	var days [7][]tado.ScheduleBlock
+	for i, segments := range w {
+		days[i] = dayBlocks(segments)
+	}
+
+	t := &Timetable{Type: tado.TimetableSevenDay}
+	switch {
+	case sameDays(days[:]):
+		t.Type = tado.TimetableOneDay
+		t.Blocks = withDayType(days[0], tado.DayTypeMondayToSunday)
+	case sameDays(days[:5]):
+		t.Type = tado.TimetableThreeDay
+		t.Blocks = withDayType(days[0], tado.DayTypeMondayToFriday)
+		t.Blocks = append(t.Blocks, withDayType(days[5], tado.DayTypeSaturday)...)
+		t.Blocks = append(t.Blocks, withDayType(days[6], tado.DayTypeSunday)...)
+	default:
+		for i, blocks := range days {
+			t.Blocks = append(t.Blocks, withDayType(blocks, weekdays[i])...)
+		}
+	}
+
+	return t
+}
+
+// dayBlocks returns blocks covering a whole day from the given segments.
+// Periods not covered by any segment are switched off, overlapping segments
+// are truncated in favour of the earlier one, and adjacent blocks with the
+// same setting are merged.
+func dayBlocks(segments []segment) []tado.ScheduleBlock {
+	segments = slices.Clone(segments)
+	slices.SortStableFunc(segments, func(a, b segment) int {
+		return a.start - b.start
+	})
+
+	var blocks []tado.ScheduleBlock
+	cursor := 0
+	appendBlock := func(start, end int, setting tado.ZoneSetting) {
+		if n := len(blocks); n > 0 && sameSetting(blocks[n-1].Setting, setting) {
+			blocks[n-1].End = formatTime(end)
+			return
+		}
+		blocks = append(blocks, tado.ScheduleBlock{
+			Start:   formatTime(start),
+			End:     formatTime(end),
+			Setting: setting,
+		})
+	}
+
+	for _, s := range segments {
+		start := max(s.start, cursor)
+		if start >= s.end {
+			continue
+		}
+		if start > cursor {
+			appendBlock(cursor, start, tado.OffSetting(tado.ZoneTypeHeating))
+		}
+		appendBlock(start, s.end, s.setting)
+		cursor = s.end
+	}
+
+	if cursor < minutesPerDay {
+		appendBlock(cursor, minutesPerDay, tado.OffSetting(tado.ZoneTypeHeating))
+	}
+
+	return blocks
+}
+
+// withDayType returns a copy of blocks with the given day type.
+func withDayType(blocks []tado.ScheduleBlock, dayType tado.DayType) []tado.ScheduleBlock {
+	blocks = slices.Clone(blocks)
+	for i := range blocks {
+		blocks[i].DayType = dayType
+	}
+
+	return blocks
+}
+
+// sameDays reports whether all given days have the same blocks.
+func sameDays(days [][]tado.ScheduleBlock) bool {
+	for _, day := range days[1:] {
+		if !slices.EqualFunc(days[0], day, func(a, b tado.ScheduleBlock) bool {
+			return a.Start == b.Start && a.End == b.End && sameSetting(a.Setting, b.Setting)
+		}) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameSetting reports whether a and b are the same setting.
+func sameSetting(a, b tado.ZoneSetting) bool {
+	if a.Type != b.Type || a.Power != b.Power {
+		return false
+	}
+
+	if a.Temperature == nil || b.Temperature == nil {
+		return a.Temperature == nil && b.Temperature == nil
+	}
+
+	return *a.Temperature == *b.Temperature
+}
+
+// heatingSetting returns a heating setting with the given temperature in the
+// given unit.
+func heatingSetting(value float64, unit tado.TemperatureUnit) tado.ZoneSetting {
+	temperature := tado.NewTemperature(value, unit)
+	return tado.ZoneSetting{Type: tado.ZoneTypeHeating, Power: tado.PowerOn, Temperature: &temperature}
+}
+
+// parseClock returns the minutes since midnight of a time of day formatted as
+// "15:04" or "15:04:05". "24:00" denotes the end of the day.
+func parseClock(v string) (int, error) {
+	parts := strings.Split(strings.TrimSpace(v), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid time %q", v)
+	}
+
+	h, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", v)
+	}
+
+	m, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", v)
+	}
+
+	minutes := h*60 + m
+	if h < 0 || m < 0 || m >= 60 || minutes > minutesPerDay {
+		return 0, fmt.Errorf("invalid time %q", v)
+	}
+
+	return minutes, nil
+}
+
+// parseWeekday returns the index of the named weekday, Monday first. Names are
+// case-insensitive and may be abbreviated to three letters.
+func parseWeekday(name string) (int, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if len(name) < 3 {
+		return 0, false
+	}
+
+	for i, day := range weekdays {
+		if strings.HasPrefix(string(day), name) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,327 @@
+package schedule
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// describe returns the blocks of t formatted as "DAY_TYPE 15:04-15:04
+// setting", with the temperature of the setting in degrees Celsius.
+func describe(t *Timetable) []string {
+	var blocks []string
+	for _, block := range t.Blocks {
+		setting := "off"
+		if block.Setting.Power == tado.PowerOn && block.Setting.Temperature != nil {
+			setting = fmt.Sprintf("%g", block.Setting.Temperature.Celsius)
+		}
+		blocks = append(blocks, fmt.Sprintf("%s %s-%s %s", block.DayType, block.Start, block.End, setting))
+	}
+
+	return blocks
+}
+
+func TestImportHomeAssistant(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantType tado.TimetableType
+		want     []string
+	}{
+		{
+			name: "every day until midnight",
+			input: `
+monday: [{from: "07:00:00", to: "24:00:00", data: {temperature: 21}}]
+tuesday: [{from: "07:00:00", to: "24:00:00", data: {temperature: 21}}]
+wednesday: [{from: "07:00:00", to: "24:00:00", data: {temperature: 21}}]
+thursday: [{from: "07:00:00", to: "24:00:00", data: {temperature: 21}}]
+friday: [{from: "07:00:00", to: "24:00:00", data: {temperature: 21}}]
+saturday: [{from: "07:00:00", to: "24:00:00", data: {temperature: 21}}]
+sunday: [{from: "07:00:00", to: "24:00:00", data: {temperature: 21}}]
+`,
+			wantType: tado.TimetableOneDay,
+			want: []string{
+				"MONDAY_TO_SUNDAY 00:00-07:00 off",
+				"MONDAY_TO_SUNDAY 07:00-00:00 21",
+			},
+		},
+		{
+			name: "from midnight in a schedule",
+			input: `
+schedule:
+  heating:
+    monday: [{from: "00:00:00", to: "06:30:00", data: {temperature: 17.5}}, {from: "06:30:00", to: "24:00:00", data: {temperature: 20}}]
+    tuesday: [{from: "00:00:00", to: "06:30:00", data: {temperature: 17.5}}, {from: "06:30:00", to: "24:00:00", data: {temperature: 20}}]
+    wednesday: [{from: "00:00:00", to: "06:30:00", data: {temperature: 17.5}}, {from: "06:30:00", to: "24:00:00", data: {temperature: 20}}]
+    thursday: [{from: "00:00:00", to: "06:30:00", data: {temperature: 17.5}}, {from: "06:30:00", to: "24:00:00", data: {temperature: 20}}]
+    friday: [{from: "00:00:00", to: "06:30:00", data: {temperature: 17.5}}, {from: "06:30:00", to: "24:00:00", data: {temperature: 20}}]
+    saturday: [{from: "00:00:00", to: "24:00:00", data: {temperature: 20}}]
+`,
+			wantType: tado.TimetableThreeDay,
+			want: []string{
+				"MONDAY_TO_FRIDAY 00:00-06:30 17.5",
+				"MONDAY_TO_FRIDAY 06:30-00:00 20",
+				"SATURDAY 00:00-00:00 20",
+				"SUNDAY 00:00-00:00 off",
+			},
+		},
+		{
+			name: "different weekdays",
+			input: `
+monday: [{from: "08:00", to: "17:00", data: {temperature: 19}}]
+friday: [{from: "08:00", to: "12:00", data: {temperature: 19}}]
+`,
+			wantType: tado.TimetableSevenDay,
+			want: []string{
+				"MONDAY 00:00-08:00 off",
+				"MONDAY 08:00-17:00 19",
+				"MONDAY 17:00-00:00 off",
+				"TUESDAY 00:00-00:00 off",
+				"WEDNESDAY 00:00-00:00 off",
+				"THURSDAY 00:00-00:00 off",
+				"FRIDAY 00:00-08:00 off",
+				"FRIDAY 08:00-12:00 19",
+				"FRIDAY 12:00-00:00 off",
+				"SATURDAY 00:00-00:00 off",
+				"SUNDAY 00:00-00:00 off",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ImportHomeAssistant(strings.NewReader(tt.input), tado.UnitCelsius)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Type != tt.wantType {
+				t.Errorf("got timetable type %s, want %s", got.Type.Type, tt.wantType.Type)
+			}
+			if blocks := describe(got); !reflect.DeepEqual(blocks, tt.want) {
+				t.Errorf("got blocks %q, want %q", blocks, tt.want)
+			}
+			if findings := Lint(got); findings != nil {
+				t.Errorf("got findings %v, want none", findings)
+			}
+		})
+	}
+}
+
+func TestImportHomeAssistant_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "ending at 00:00",
+			input:   `monday: [{from: "22:00:00", to: "00:00:00", data: {temperature: 21}}]`,
+			wantErr: "monday: slot 22:00:00-00:00:00 does not end after it starts, use 24:00:00 for midnight",
+		},
+		{
+			name:    "ending before it starts",
+			input:   `sunday: [{from: "09:00", to: "08:00", data: {temperature: 21}}]`,
+			wantErr: "sunday: slot 09:00-08:00 does not end after it starts, use 24:00:00 for midnight",
+		},
+		{
+			name:    "after midnight",
+			input:   `monday: [{from: "22:00", to: "24:30", data: {temperature: 21}}]`,
+			wantErr: `monday: invalid time "24:30"`,
+		},
+		{
+			name:    "without temperature",
+			input:   `tuesday: [{from: "07:00", to: "09:00", data: {mode: comfort}}]`,
+			wantErr: "tuesday: slot 07:00-09:00 has no temperature",
+		},
+		{
+			name: "more than one schedule",
+			input: `
+schedule:
+  heating: {monday: [{from: "07:00", to: "09:00", data: {temperature: 21}}]}
+  cooling: {monday: [{from: "07:00", to: "09:00", data: {temperature: 25}}]}
+`,
+			wantErr: "Home Assistant file holds more than one schedule",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportHomeAssistant(strings.NewReader(tt.input), tado.UnitCelsius)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestImportNestCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		unit     tado.TemperatureUnit
+		wantType tado.TimetableType
+		want     []string
+	}{
+		{
+			name: "every day",
+			input: `day,time,temperature
+Monday,07:00,21
+Monday,22:00,18
+Tuesday,07:00,21
+Tuesday,22:00,18
+Wednesday,07:00,21
+Wednesday,22:00,18
+Thursday,07:00,21
+Thursday,22:00,18
+Friday,07:00,21
+Friday,22:00,18
+Saturday,07:00,21
+Saturday,22:00,18
+Sunday,07:00,21
+Sunday,22:00,18
+`,
+			unit:     tado.UnitCelsius,
+			wantType: tado.TimetableOneDay,
+			want: []string{
+				"MONDAY_TO_SUNDAY 00:00-07:00 18",
+				"MONDAY_TO_SUNDAY 07:00-22:00 21",
+				"MONDAY_TO_SUNDAY 22:00-00:00 18",
+			},
+		},
+		{
+			name: "wrapping from Sunday to Monday",
+			input: `day,time,temperature
+Monday,07:00,21
+Sunday,22:00,off
+`,
+			unit:     tado.UnitCelsius,
+			wantType: tado.TimetableSevenDay,
+			want: []string{
+				"MONDAY 00:00-07:00 off",
+				"MONDAY 07:00-00:00 21",
+				"TUESDAY 00:00-00:00 21",
+				"WEDNESDAY 00:00-00:00 21",
+				"THURSDAY 00:00-00:00 21",
+				"FRIDAY 00:00-00:00 21",
+				"SATURDAY 00:00-00:00 21",
+				"SUNDAY 00:00-22:00 21",
+				"SUNDAY 22:00-00:00 off",
+			},
+		},
+		{
+			name: "Sunday until midnight",
+			input: `temperature,day,time
+21,mon,06:00
+OFF,fri,23:00
+18,sun,24:00
+`,
+			unit:     tado.UnitCelsius,
+			wantType: tado.TimetableSevenDay,
+			want: []string{
+				"MONDAY 00:00-06:00 18",
+				"MONDAY 06:00-00:00 21",
+				"TUESDAY 00:00-00:00 21",
+				"WEDNESDAY 00:00-00:00 21",
+				"THURSDAY 00:00-00:00 21",
+				"FRIDAY 00:00-23:00 21",
+				"FRIDAY 23:00-00:00 off",
+				"SATURDAY 00:00-00:00 off",
+				"SUNDAY 00:00-00:00 off",
+			},
+		},
+		{
+			name: "weekends in Fahrenheit",
+			input: `day,time,temperature
+Mon,06:00,68
+Mon,23:00,59
+Tue,06:00,68
+Tue,23:00,59
+Wed,06:00,68
+Wed,23:00,59
+Thu,06:00,68
+Thu,23:00,59
+Fri,06:00,68
+Fri,23:00,59
+Sat,09:00,68
+Sun,22:00,59
+`,
+			unit:     tado.UnitFahrenheit,
+			wantType: tado.TimetableThreeDay,
+			want: []string{
+				"MONDAY_TO_FRIDAY 00:00-06:00 15",
+				"MONDAY_TO_FRIDAY 06:00-23:00 20",
+				"MONDAY_TO_FRIDAY 23:00-00:00 15",
+				"SATURDAY 00:00-09:00 15",
+				"SATURDAY 09:00-00:00 20",
+				"SUNDAY 00:00-22:00 20",
+				"SUNDAY 22:00-00:00 15",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ImportNestCSV(strings.NewReader(tt.input), tt.unit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got.Type != tt.wantType {
+				t.Errorf("got timetable type %s, want %s", got.Type.Type, tt.wantType.Type)
+			}
+			if blocks := describe(got); !reflect.DeepEqual(blocks, tt.want) {
+				t.Errorf("got blocks %q, want %q", blocks, tt.want)
+			}
+			if findings := Lint(got); findings != nil {
+				t.Errorf("got findings %v, want none", findings)
+			}
+		})
+	}
+}
+
+func TestImportNestCSV_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "without time column",
+			input:   "day,temperature\nMonday,21\n",
+			wantErr: "Nest CSV has no time column",
+		},
+		{
+			name:    "without setpoints",
+			input:   "day,time,temperature\n",
+			wantErr: "Nest CSV has no setpoints",
+		},
+		{
+			name:    "invalid day",
+			input:   "day,time,temperature\nMo,07:00,21\n",
+			wantErr: `invalid day "Mo"`,
+		},
+		{
+			name:    "invalid time",
+			input:   "day,time,temperature\nMonday,25:00,21\n",
+			wantErr: `invalid time "25:00"`,
+		},
+		{
+			name:    "invalid temperature",
+			input:   "day,time,temperature\nMonday,07:00,warm\n",
+			wantErr: `invalid temperature "warm"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportNestCSV(strings.NewReader(tt.input), tado.UnitCelsius)
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
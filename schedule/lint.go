@@ -0,0 +1,209 @@
+package schedule
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// minutesPerDay is the number of minutes in a day, and the end of the last
+// block of a day.
+const minutesPerDay = 24 * 60
+
+// Kind represents the kind of a finding.
+type Kind string
+
+const (
+	// Invalid findings are blocks or timetables the API rejects, e.g. blocks
+	// with malformed times.
+	Invalid Kind = "INVALID"
+	// Gap findings are periods of a day not covered by any block.
+	Gap Kind = "GAP"
+	// Overlap findings are periods of a day covered by more than one block.
+	Overlap Kind = "OVERLAP"
+	// Unreachable findings are blocks that never apply, e.g. because they are
+	// empty or belong to a day type the timetable does not use.
+	Unreachable Kind = "UNREACHABLE"
+	// AwayInconsistent findings are blocks that heat less than the away
+	// configuration, so that leaving home raises the temperature.
+	AwayInconsistent Kind = "AWAY_INCONSISTENT"
+)
+
+// Finding is a problem of a timetable found by Lint. Start and End delimit the
+// affected period of the day, formatted like the blocks of a timetable.
+type Finding struct {
+	Kind    Kind         `json:"kind"`
+	DayType tado.DayType `json:"dayType"`
+	Start   string       `json:"start,omitempty"`
+	End     string       `json:"end,omitempty"`
+	Message string       `json:"message"`
+}
+
+// String returns a human-readable description of the finding.
+func (f Finding) String() string {
+	if f.Start == "" && f.End == "" {
+		return fmt.Sprintf("%s: %s", f.DayType, f.Message)
+	}
+
+	return fmt.Sprintf("%s %s-%s: %s", f.DayType, f.Start, f.End, f.Message)
+}
+
+// Lint checks t for gaps, overlaps, unreachable blocks and blocks that are
+// inconsistent with the away configuration, and returns its findings. A
+// timetable without findings covers every day of its type exactly once.
+func Lint(t *Timetable) []Finding {
+	dayTypes := t.Type.DayTypes()
+	if dayTypes == nil {
+		return []Finding{{
+			Kind:    Invalid,
+			Message: fmt.Sprintf("unknown timetable type %d", t.Type.ID),
+		}}
+	}
+
+	var findings []Finding
+	for _, block := range t.Blocks {
+		if !slices.Contains(dayTypes, block.DayType) {
+			findings = append(findings, blockFinding(Unreachable, block,
+				fmt.Sprintf("day type is not used by %s timetables", t.Type.Type)))
+		}
+	}
+
+	for _, dayType := range dayTypes {
+		findings = append(findings, lintDay(dayType, t.BlocksOf(dayType))...)
+	}
+
+	if away := awayTemperature(t.Away); away != nil {
+		for _, block := range t.Blocks {
+			setting := block.Setting
+			if block.GeolocationOverride || setting.Power != tado.PowerOn || setting.Temperature == nil {
+				continue
+			}
+
+			if setting.Temperature.Celsius < away.Celsius {
+				findings = append(findings, blockFinding(AwayInconsistent, block,
+					fmt.Sprintf("%.1f°C is below the away temperature of %.1f°C", setting.Temperature.Celsius, away.Celsius)))
+			}
+		}
+	}
+
+	return findings
+}
+
+// span is a block with its start and end in minutes since midnight.
+type span struct {
+	block      tado.ScheduleBlock
+	start, end int
+}
+
+// lintDay returns the findings of the blocks of a single day type.
+func lintDay(dayType tado.DayType, blocks []tado.ScheduleBlock) []Finding {
+	var findings []Finding
+
+	var spans []span
+	for _, block := range blocks {
+		start, err := parseTime(block.Start, false)
+		if err != nil {
+			findings = append(findings, blockFinding(Invalid, block, err.Error()))
+			continue
+		}
+
+		end, err := parseTime(block.End, true)
+		if err != nil {
+			findings = append(findings, blockFinding(Invalid, block, err.Error()))
+			continue
+		}
+
+		if start >= end {
+			findings = append(findings, blockFinding(Unreachable, block, "block ends before it starts"))
+			continue
+		}
+
+		spans = append(spans, span{block: block, start: start, end: end})
+	}
+
+	slices.SortStableFunc(spans, func(a, b span) int {
+		return a.start - b.start
+	})
+
+	cursor := 0
+	for _, s := range spans {
+		switch {
+		case s.start > cursor:
+			findings = append(findings, Finding{
+				Kind:    Gap,
+				DayType: dayType,
+				Start:   formatTime(cursor),
+				End:     formatTime(s.start),
+				Message: "no block covers this period",
+			})
+		case s.start < cursor:
+			findings = append(findings, Finding{
+				Kind:    Overlap,
+				DayType: dayType,
+				Start:   formatTime(s.start),
+				End:     formatTime(min(cursor, s.end)),
+				Message: "more than one block covers this period",
+			})
+		}
+
+		cursor = max(cursor, s.end)
+	}
+
+	if cursor < minutesPerDay {
+		findings = append(findings, Finding{
+			Kind:    Gap,
+			DayType: dayType,
+			Start:   formatTime(cursor),
+			End:     formatTime(minutesPerDay),
+			Message: "no block covers this period",
+		})
+	}
+
+	return findings
+}
+
+// awayTemperature returns the temperature of the given away configuration, or
+// nil if it does not heat.
+func awayTemperature(away *tado.AwayConfiguration) *tado.Temperature {
+	if away == nil || away.Setting == nil || away.Setting.Power != tado.PowerOn {
+		return nil
+	}
+
+	return away.Setting.Temperature
+}
+
+// blockFinding returns a finding of the given kind for block.
+func blockFinding(kind Kind, block tado.ScheduleBlock, message string) Finding {
+	return Finding{
+		Kind:    kind,
+		DayType: block.DayType,
+		Start:   block.Start,
+		End:     block.End,
+		Message: message,
+	}
+}
+
+// parseTime returns the minutes since midnight of a time of day formatted as
+// "15:04". If end is true, "00:00" denotes the end of the day.
+func parseTime(v string, end bool) (int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q", v)
+	}
+
+	minutes := t.Hour()*60 + t.Minute()
+	if end && minutes == 0 {
+		return minutesPerDay, nil
+	}
+
+	return minutes, nil
+}
+
+// formatTime formats minutes since midnight as "15:04", using "00:00" for the
+// end of the day.
+func formatTime(minutes int) string {
+	minutes %= minutesPerDay
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
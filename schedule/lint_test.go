@@ -0,0 +1,123 @@
+package schedule
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// block returns a heating block of the given day type with the given
+// temperature in degrees Celsius.
+func block(dayType tado.DayType, start, end string, celsius float64) tado.ScheduleBlock {
+	return tado.ScheduleBlock{DayType: dayType, Start: start, End: end, Setting: heatingSetting(celsius, tado.UnitCelsius)}
+}
+
+func TestLint(t *testing.T) {
+	awaySetting := heatingSetting(16, tado.UnitCelsius)
+	away := &tado.AwayConfiguration{Type: tado.ZoneTypeHeating, Setting: &awaySetting}
+
+	tests := []struct {
+		name      string
+		timetable Timetable
+		want      []Finding
+	}{
+		{
+			name: "whole day ending at midnight",
+			timetable: Timetable{Type: tado.TimetableOneDay, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToSunday, "00:00", "07:00", 18),
+				block(tado.DayTypeMondayToSunday, "07:00", "00:00", 21),
+			}},
+		},
+		{
+			name: "single block of the whole day",
+			timetable: Timetable{Type: tado.TimetableOneDay, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToSunday, "00:00", "00:00", 21),
+			}},
+		},
+		{
+			name: "gaps",
+			timetable: Timetable{Type: tado.TimetableOneDay, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToSunday, "06:00", "12:00", 21),
+				block(tado.DayTypeMondayToSunday, "13:00", "23:30", 21),
+			}},
+			want: []Finding{
+				{Kind: Gap, DayType: tado.DayTypeMondayToSunday, Start: "00:00", End: "06:00", Message: "no block covers this period"},
+				{Kind: Gap, DayType: tado.DayTypeMondayToSunday, Start: "12:00", End: "13:00", Message: "no block covers this period"},
+				{Kind: Gap, DayType: tado.DayTypeMondayToSunday, Start: "23:30", End: "00:00", Message: "no block covers this period"},
+			},
+		},
+		{
+			name: "overlap",
+			timetable: Timetable{Type: tado.TimetableOneDay, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToSunday, "00:00", "08:00", 18),
+				block(tado.DayTypeMondayToSunday, "07:00", "00:00", 21),
+			}},
+			want: []Finding{
+				{Kind: Overlap, DayType: tado.DayTypeMondayToSunday, Start: "07:00", End: "08:00", Message: "more than one block covers this period"},
+			},
+		},
+		{
+			name: "block ending before it starts",
+			timetable: Timetable{Type: tado.TimetableOneDay, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToSunday, "00:00", "00:00", 21),
+				block(tado.DayTypeMondayToSunday, "08:00", "07:00", 21),
+			}},
+			want: []Finding{
+				{Kind: Unreachable, DayType: tado.DayTypeMondayToSunday, Start: "08:00", End: "07:00", Message: "block ends before it starts"},
+			},
+		},
+		{
+			name: "invalid time",
+			timetable: Timetable{Type: tado.TimetableOneDay, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToSunday, "00:00", "24:00", 21),
+			}},
+			want: []Finding{
+				{Kind: Invalid, DayType: tado.DayTypeMondayToSunday, Start: "00:00", End: "24:00", Message: `invalid time "24:00"`},
+				{Kind: Gap, DayType: tado.DayTypeMondayToSunday, Start: "00:00", End: "00:00", Message: "no block covers this period"},
+			},
+		},
+		{
+			name: "day type not used by the timetable type",
+			timetable: Timetable{Type: tado.TimetableThreeDay, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToFriday, "00:00", "00:00", 21),
+				block(tado.DayTypeSaturday, "00:00", "00:00", 21),
+				block(tado.DayTypeSunday, "00:00", "00:00", 21),
+				block(tado.DayTypeMonday, "00:00", "00:00", 21),
+			}},
+			want: []Finding{
+				{Kind: Unreachable, DayType: tado.DayTypeMonday, Start: "00:00", End: "00:00", Message: "day type is not used by THREE_DAY timetables"},
+			},
+		},
+		{
+			name:      "unknown timetable type",
+			timetable: Timetable{Type: tado.TimetableType{ID: 3}},
+			want:      []Finding{{Kind: Invalid, Message: "unknown timetable type 3"}},
+		},
+		{
+			name: "below the away temperature",
+			timetable: Timetable{Type: tado.TimetableOneDay, Away: away, Blocks: []tado.ScheduleBlock{
+				block(tado.DayTypeMondayToSunday, "00:00", "07:00", 15),
+				block(tado.DayTypeMondayToSunday, "07:00", "00:00", 21),
+			}},
+			want: []Finding{
+				{Kind: AwayInconsistent, DayType: tado.DayTypeMondayToSunday, Start: "00:00", End: "07:00", Message: "15.0°C is below the away temperature of 16.0°C"},
+			},
+		},
+		{
+			name: "below the away temperature with geolocation override",
+			timetable: Timetable{Type: tado.TimetableOneDay, Away: away, Blocks: []tado.ScheduleBlock{
+				{DayType: tado.DayTypeMondayToSunday, Start: "00:00", End: "07:00", GeolocationOverride: true, Setting: heatingSetting(15, tado.UnitCelsius)},
+				block(tado.DayTypeMondayToSunday, "07:00", "00:00", 21),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lint(&tt.timetable); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got findings %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package schedule reads, validates and writes the timetables of Tado zones,
+// so that programmatic schedule edits can be checked before they are
+// uploaded.
+package schedule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// Timetable is the complete program of a zone: the active timetable type, its
+// blocks for every day type, and optionally the away configuration of the
+// zone.
+type Timetable struct {
+	Type   tado.TimetableType      `json:"type"`
+	Blocks []tado.ScheduleBlock    `json:"blocks"`
+	Away   *tado.AwayConfiguration `json:"away,omitempty"`
+}
+
+// BlocksOf returns the blocks of the timetable for the given day type.
+func (t *Timetable) BlocksOf(dayType tado.DayType) []tado.ScheduleBlock {
+	var blocks []tado.ScheduleBlock
+	for _, block := range t.Blocks {
+		if block.DayType == dayType {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// Fetch returns the active timetable of the zone with the given ID of the
+// provided home ID, including its away configuration.
+func Fetch(ctx context.Context, client *tado.Client, homeID tado.HomeID, zoneID tado.ZoneID) (*Timetable, error) {
+	active, err := client.Zone.GetActiveTimetable(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := client.Zone.GetScheduleBlocks(ctx, homeID, zoneID, active.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	away, err := client.Zone.GetAwayConfiguration(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Timetable{Type: *active, Blocks: blocks, Away: away}, nil
+}
+
+// LintError is returned by Upload when a timetable has findings.
+type LintError struct {
+	Findings []Finding
+}
+
+func (e *LintError) Error() string {
+	switch len(e.Findings) {
+	case 0:
+		return "schedule: no findings"
+	case 1:
+		return fmt.Sprintf("schedule: %v", e.Findings[0])
+	default:
+		return fmt.Sprintf("schedule: %v (and %d more findings)", e.Findings[0], len(e.Findings)-1)
+	}
+}
+
+// Upload makes t the active timetable of the zone with the given ID of the
+// provided home ID and replaces its blocks. The away configuration is not
+// written. If Lint reports any findings, nothing is written and a *LintError
+// is returned.
+func Upload(ctx context.Context, client *tado.Client, homeID tado.HomeID, zoneID tado.ZoneID, t *Timetable) error {
+	if findings := Lint(t); len(findings) > 0 {
+		return &LintError{Findings: findings}
+	}
+
+	if _, err := client.Zone.SetActiveTimetable(ctx, homeID, zoneID, t.Type); err != nil {
+		return err
+	}
+
+	for _, dayType := range t.Type.DayTypes() {
+		if _, err := client.Zone.SetScheduleBlocks(ctx, homeID, zoneID, t.Type.ID, dayType, t.BlocksOf(dayType)); err != nil {
+			return fmt.Errorf("uploading %s blocks: %w", dayType, err)
+		}
+	}
+
+	return nil
+}
@@ -3,6 +3,10 @@ package tado
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 
 	"golang.org/x/oauth2"
 )
@@ -12,6 +16,10 @@ type Authenticator interface {
 	TokenSource(context.Context) (oauth2.TokenSource, error)
 }
 
+// AuthHeaderProvider returns the value of the Authorization header to send
+// with a request, e.g. a static gateway secret or a token obtained elsewhere.
+type AuthHeaderProvider func(ctx context.Context) (string, error)
+
 var TadoDeviceAuthClientID = "1bb50063-6b0c-4d11-bd99-387f4a91cc46"
 var TadoDeviceAuthURL = "https://login.tado.com/oauth2/device_authorize"
 var TadoDeviceAuthTokenURL = "https://login.tado.com/oauth2/token"
@@ -34,43 +42,298 @@ var TadoDeviceAuthDefaultOAuth2Config = &oauth2.Config{
 //
 // The DeviceAuthenticator can be initialized with a custom oauth2.Config, or it
 // defaults to TadoDeviceAuthDefaultOAuth2Config if none is provided.
+//
+// If a TokenStore is configured using WithTokenStore, a stored token is reused
+// instead of starting the device flow, and refreshed tokens are saved to the
+// store.
 type DeviceAuthenticator struct {
 	config *oauth2.Config
+	store  TokenStore
+	prompt DeviceAuthPrompt
+	scopes []string
+}
+
+// DeviceAuthPrompt asks the user to visit the verification URI of the given
+// device authorization response, e.g. by logging it, rendering a QR code or
+// sending a push notification. Returning an error aborts the device flow.
+type DeviceAuthPrompt func(ctx context.Context, da *oauth2.DeviceAuthResponse) error
+
+// DeviceAuthenticatorOption configures a DeviceAuthenticator.
+type DeviceAuthenticatorOption func(*DeviceAuthenticator)
+
+// WithTokenStore makes the DeviceAuthenticator load tokens from and save
+// tokens to the given store.
+func WithTokenStore(store TokenStore) DeviceAuthenticatorOption {
+	return func(a *DeviceAuthenticator) {
+		a.store = store
+	}
+}
+
+// WithDeviceAuthPrompt sets the prompt used to ask the user to log in. By
+// default, the verification URI is printed to stdout.
+func WithDeviceAuthPrompt(prompt DeviceAuthPrompt) DeviceAuthenticatorOption {
+	return func(a *DeviceAuthenticator) {
+		a.prompt = prompt
+	}
+}
+
+// WithScopes requests the given scopes instead of the scopes of the
+// oauth2.Config, e.g. so that a monitoring deployment holds a token that
+// cannot perform writes. A stored token granting scopes beyond the requested
+// ones is not reused.
+//
+// Tado currently only grants the "offline-access" scope; narrower scopes take
+// effect once Tado supports them.
+func WithScopes(scopes ...string) DeviceAuthenticatorOption {
+	return func(a *DeviceAuthenticator) {
+		a.scopes = scopes
+	}
+}
+
+// printDeviceAuthPrompt is the default DeviceAuthPrompt, printing the
+// verification URI to stdout.
+func printDeviceAuthPrompt(_ context.Context, da *oauth2.DeviceAuthResponse) error {
+	_, err := fmt.Printf("Visit %s to log in.\n", da.VerificationURIComplete)
+	return err
 }
 
 // NewDeviceAuthenticator creates a new DeviceAuthenticator.
 //
 // If the provided config is nil, it defaults to
 // TadoDeviceAuthDefaultOAuth2Config.
-func NewDeviceAuthenticator(config *oauth2.Config) *DeviceAuthenticator {
+func NewDeviceAuthenticator(config *oauth2.Config, opts ...DeviceAuthenticatorOption) *DeviceAuthenticator {
 	c := config
 
 	if c == nil {
 		c = TadoDeviceAuthDefaultOAuth2Config
 	}
 
-	return &DeviceAuthenticator{
+	a := &DeviceAuthenticator{
 		config: c,
+		prompt: printDeviceAuthPrompt,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	if a.scopes != nil {
+		scoped := *a.config
+		scoped.Scopes = a.scopes
+		a.config = &scoped
 	}
+
+	return a
 }
 
 // TokenSource implements the Authenticator interface.
 //
-// It is a blocking call that asks the user to visit the verification URI and
-// enter the user code. Once the user has done so, it returns a TokenSource for
-// the authenticated user.
+// It is a blocking call that asks the user, using the configured
+// DeviceAuthPrompt, to visit the verification URI and enter the user code.
+// Once the user has done so, it returns a TokenSource for the authenticated
+// user.
+//
+// If a TokenStore is configured and holds a token, no device flow is started.
 func (a *DeviceAuthenticator) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
-	deviceCode, err := a.config.DeviceAuth(ctx)
+	var token *oauth2.Token
+	if a.store != nil {
+		stored, err := a.store.Load()
+		if err != nil {
+			return nil, fmt.Errorf("loading token: %w", err)
+		}
+		if stored != nil && a.grantsOnlyRequestedScopes(stored) {
+			token = stored
+		}
+	}
+
+	if token == nil {
+		deviceCode, err := a.config.DeviceAuth(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := a.prompt(ctx, deviceCode); err != nil {
+			return nil, err
+		}
+
+		token, err = a.config.DeviceAccessToken(ctx, deviceCode)
+		if err != nil {
+			return nil, err
+		}
+
+		if a.store != nil {
+			if err := a.store.Save(token); err != nil {
+				return nil, fmt.Errorf("saving token: %w", err)
+			}
+		}
+	}
+
+	ts := a.config.TokenSource(ctx, token)
+	if a.store != nil {
+		ts = &storingTokenSource{base: ts, store: a.store, last: token}
+	}
+
+	return ts, nil
+}
+
+// grantsOnlyRequestedScopes reports whether the given token grants no scopes
+// beyond the ones requested with WithScopes. Tokens that do not report their
+// scopes are assumed to grant the requested ones.
+func (a *DeviceAuthenticator) grantsOnlyRequestedScopes(token *oauth2.Token) bool {
+	if a.scopes == nil {
+		return true
+	}
+
+	granted, _ := token.Extra("scope").(string)
+	for _, scope := range strings.Fields(granted) {
+		if !slices.Contains(a.scopes, scope) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// authHeaderTransport is an http.RoundTripper that sets the Authorization
+// header of every request using an AuthHeaderProvider.
+type authHeaderTransport struct {
+	provider AuthHeaderProvider
+	base     http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *authHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	header, err := t.provider(req.Context())
 	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
 		return nil, err
 	}
 
-	fmt.Printf("Visit %s to log in.\n", deviceCode.VerificationURIComplete)
+	req = req.Clone(req.Context())
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+
+	base := t.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return base.RoundTrip(req)
+}
 
-	token, err := a.config.DeviceAccessToken(ctx, deviceCode)
+// lazyTokenTransport is an http.RoundTripper that acquires a TokenSource from
+// an Authenticator when the first request is sent, and authorizes requests
+// using it.
+type lazyTokenTransport struct {
+	ctx           context.Context
+	authenticator Authenticator
+	base          http.RoundTripper
+
+	mu      sync.Mutex
+	source  *recordingTokenSource
+	err     error
+	pending chan struct{}
+}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (t *lazyTokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	source, err := t.tokenSource(req.Context())
 	if err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
 		return nil, err
 	}
 
-	return a.config.TokenSource(ctx, token), nil
+	transport := &oauth2.Transport{Source: source, Base: t.base}
+	return transport.RoundTrip(req)
+}
+
+// tokenSource returns the TokenSource, acquiring it if needed. Acquisition
+// happens in the background using the context of the client, so that a slow
+// device flow is not aborted when ctx, the context of a single request, is
+// done.
+func (t *lazyTokenTransport) tokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	t.mu.Lock()
+	if t.source != nil {
+		source := t.source
+		t.mu.Unlock()
+		return source, nil
+	}
+
+	if t.pending == nil {
+		done := make(chan struct{})
+		t.pending = done
+
+		go func() {
+			var recording *recordingTokenSource
+			source, err := t.authenticator.TokenSource(t.ctx)
+			if err == nil {
+				recording = &recordingTokenSource{source: oauth2.ReuseTokenSource(nil, source)}
+			}
+
+			t.mu.Lock()
+			t.source, t.err, t.pending = recording, err, nil
+			t.mu.Unlock()
+			close(done)
+		}()
+	}
+	pending := t.pending
+	t.mu.Unlock()
+
+	select {
+	case <-pending:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.source == nil {
+		return nil, fmt.Errorf("acquiring token: %w", t.err)
+	}
+
+	return t.source, nil
+}
+
+// current returns the TokenSource if it has been acquired, or nil otherwise.
+func (t *lazyTokenTransport) current() *recordingTokenSource {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.source
+}
+
+// recordingTokenSource is an oauth2.TokenSource that records the last token
+// or error returned by source, so that they can be inspected without
+// refreshing the token.
+type recordingTokenSource struct {
+	source oauth2.TokenSource
+
+	mu    sync.Mutex
+	token *oauth2.Token
+	err   error
+}
+
+// Token implements the oauth2.TokenSource interface.
+func (s *recordingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+
+	s.mu.Lock()
+	s.token, s.err = token, err
+	s.mu.Unlock()
+
+	return token, err
+}
+
+// last returns the last token or error returned by the source. Both are nil
+// if no token has been requested yet.
+func (s *recordingTokenSource) last() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.token, s.err
 }
@@ -0,0 +1,152 @@
+package tado
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Cache stores cached API data. Implementations backed by an external store,
+// such as Redis or SQLite, allow multiple processes on one host to share
+// cached data. Values are opaque bytes, encoded with the Codec of the client.
+type Cache interface {
+	// Get returns the value stored under key, and whether it was found and
+	// has not expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key for the duration ttl. A zero ttl means the
+	// value does not expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the value stored under key, if any.
+	Delete(ctx context.Context, key string) error
+}
+
+// MemoryCache is an in-memory Cache. It is the default Cache of a Client.
+// Expired values are removed when they are read, and at most every
+// memoryCacheSweepInterval when a value is stored, so that values that are
+// never read again do not accumulate.
+type MemoryCache struct {
+	mu        sync.Mutex
+	entries   map[string]memoryCacheEntry
+	lastSweep time.Time
+}
+
+// memoryCacheSweepInterval is the minimum interval between two sweeps of the
+// expired values of a MemoryCache.
+const memoryCacheSweepInterval = time.Minute
+
+// memoryCacheEntry is a value stored in a MemoryCache.
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: map[string]memoryCacheEntry{}}
+}
+
+// Get implements the Cache interface.
+func (c *MemoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// Set implements the Cache interface.
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastSweep) >= memoryCacheSweepInterval {
+		c.sweep(now)
+	}
+
+	entry := memoryCacheEntry{value: value}
+	if ttl > 0 {
+		entry.expires = now.Add(ttl)
+	}
+	c.entries[key] = entry
+
+	return nil
+}
+
+// sweep removes the expired values. c.mu must be held.
+func (c *MemoryCache) sweep(now time.Time) {
+	for key, entry := range c.entries {
+		if !entry.expires.IsZero() && now.After(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+	c.lastSweep = now
+}
+
+// Delete implements the Cache interface.
+func (c *MemoryCache) Delete(_ context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	return nil
+}
+
+// Keys returns the keys of all unexpired values, sorted.
+func (c *MemoryCache) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	keys := make([]string, 0, len(c.entries))
+	for key, entry := range c.entries {
+		if entry.expires.IsZero() || now.Before(entry.expires) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// WithCacheStore sets the Cache used by the client, e.g. to share cached data
+// between processes. By default, a MemoryCache is used.
+func WithCacheStore(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// cacheGet decodes the value stored under key into v and reports whether it
+// was found. Cache failures are treated as misses.
+func (c *Client) cacheGet(ctx context.Context, key string, v any) bool {
+	data, ok, err := c.cache.Get(ctx, key)
+	if err != nil || !ok {
+		return false
+	}
+
+	return c.codec.Unmarshal(data, v) == nil
+}
+
+// cacheSet stores v under key for the duration ttl. Cache failures are
+// ignored, as the cache is only an optimization.
+func (c *Client) cacheSet(ctx context.Context, key string, v any, ttl time.Duration) {
+	data, err := c.codec.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	_ = c.cache.Set(ctx, key, data, ttl)
+}
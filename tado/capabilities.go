@@ -0,0 +1,105 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// GenerationLineX is the generation of Tado X homes.
+const GenerationLineX = "LINE_X"
+
+// CapabilitiesTTL is the time capabilities are cached for.
+var CapabilitiesTTL = 24 * time.Hour
+
+// Capabilities describes which API families are available for a home.
+type Capabilities struct {
+	HomeID                      HomeID `json:"homeId"`
+	Generation                  string `json:"generation"`
+	TadoX                       bool   `json:"tadoX"`
+	EnergyIQ                    bool   `json:"energyIq"`
+	AirComfort                  bool   `json:"airComfort"`
+	IncidentDetection           bool   `json:"incidentDetection"`
+	FlowTemperatureOptimization bool   `json:"flowTemperatureOptimization"`
+	RunningTimes                bool   `json:"runningTimes"`
+}
+
+// Capabilities returns the capabilities of the home with the given ID. They
+// are derived from the home details and, where needed, by probing the API.
+// The result is cached per home for CapabilitiesTTL in the cache of the
+// client; use InvalidateCapabilities to refresh it.
+func (c *Client) Capabilities(ctx context.Context, homeID HomeID) (*Capabilities, error) {
+	key := fmt.Sprintf("capabilities/%d", homeID)
+
+	var cached Capabilities
+	if c.cacheGet(ctx, key, &cached) {
+		return &cached, nil
+	}
+
+	home, err := c.Home.Get(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := &Capabilities{
+		HomeID:            homeID,
+		Generation:        home.Generation,
+		TadoX:             home.Generation == GenerationLineX,
+		EnergyIQ:          home.IsEnergyIqEligible,
+		AirComfort:        home.IsAirComfortEligible,
+		IncidentDetection: home.IncidentDetection.Supported,
+	}
+
+	if !capabilities.TadoX {
+		capabilities.FlowTemperatureOptimization, err = c.probe(ctx, fmt.Sprintf("homes/%d/flowTemperatureOptimization", homeID))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	capabilities.RunningTimes, err = c.probe(ctx, c.minderPath("homes/%d/runningTimes", homeID))
+	if err != nil {
+		return nil, err
+	}
+
+	c.cacheSet(ctx, key, capabilities, CapabilitiesTTL)
+
+	return capabilities, nil
+}
+
+// InvalidateCapabilities removes the cached capabilities of the home with the
+// given ID.
+func (c *Client) InvalidateCapabilities(ctx context.Context, homeID HomeID) error {
+	return c.cache.Delete(ctx, fmt.Sprintf("capabilities/%d", homeID))
+}
+
+// probe reports whether a GET request to the given path succeeds. Client
+// errors (4xx) are reported as false; other failures, including rejected
+// credentials (401 and 403), are returned as errors, so that a transient
+// authentication failure is not cached as a missing capability.
+func (c *Client) probe(ctx context.Context, path string) (bool, error) {
+	req, err := c.newRequest("Client", "Capabilities", "GET", path, nil)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := c.BareDo(ctx, req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode >= 200 && res.StatusCode < 300:
+		return true, nil
+	case res.StatusCode == http.StatusTooManyRequests,
+		res.StatusCode == http.StatusUnauthorized,
+		res.StatusCode == http.StatusForbidden:
+		return false, CheckResponse(res.Response)
+	case res.StatusCode >= 400 && res.StatusCode < 500:
+		return false, nil
+	default:
+		return false, fmt.Errorf("probing %s: unexpected status %s", req.URL, res.Status)
+	}
+}
@@ -0,0 +1,230 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCoalesceQuiet is the default quiet period of an OverlayCoalescer.
+var DefaultCoalesceQuiet = 2 * time.Second
+
+// ErrCoalescerClosed is returned by the methods of an OverlayCoalescer after
+// it was closed.
+var ErrCoalescerClosed = errors.New("coalescer closed")
+
+// CoalescerOptions configures an OverlayCoalescer.
+type CoalescerOptions struct {
+	// Quiet is the period without changes to a zone after which its overlay
+	// is written. It defaults to DefaultCoalesceQuiet.
+	Quiet time.Duration
+
+	// MaxDelay is the maximum time a change is delayed, so that a zone that
+	// keeps changing is still written regularly. Zero means no maximum.
+	MaxDelay time.Duration
+
+	// OnError is called with the errors of the writes made in the
+	// background, i.e. not by Flush or Close.
+	OnError func(homeID HomeID, zoneID ZoneID, err error)
+}
+
+// OverlayCoalescer delays and merges the overlay writes of zones, so that a
+// zone that is adjusted repeatedly within a short time is written only once:
+// the last overlay set or removed wins once the zone has not changed for the
+// quiet period. This reduces the number of requests as well as the radio
+// traffic to the devices. It is safe for concurrent use.
+//
+// Example usage:
+//
+//	coalescer := client.Zone.NewCoalescer(tado.CoalescerOptions{Quiet: 5 * time.Second})
+//	defer coalescer.Close(ctx)
+//
+//	for _, celsius := range []float64{20, 20.5, 21} {
+//		coalescer.SetOverlay(homeID, zoneID, tado.NewOverlay(tado.HeatingSetting(celsius), tado.ManualTermination()))
+//	}
+type OverlayCoalescer struct {
+	zones *ZoneService
+	opts  CoalescerOptions
+
+	mu      sync.Mutex
+	closed  bool
+	seq     uint64
+	pending map[zoneKey]*coalescedWrite
+	writing map[zoneKey]*zoneWriter
+	wg      sync.WaitGroup
+}
+
+// zoneWriter serializes the writes of a zone.
+type zoneWriter struct {
+	sync.Mutex
+	seq uint64 // of the last write
+}
+
+// zoneKey identifies a zone across homes.
+type zoneKey struct {
+	homeID HomeID
+	zoneID ZoneID
+}
+
+// coalescedWrite is the pending write of a zone.
+type coalescedWrite struct {
+	overlay *Overlay // nil removes the overlay
+	opts    []WriteOption
+	seq     uint64
+	since   time.Time
+	timer   *time.Timer
+}
+
+// NewCoalescer returns an OverlayCoalescer writing overlays with the service.
+func (s *ZoneService) NewCoalescer(opts CoalescerOptions) *OverlayCoalescer {
+	if opts.Quiet <= 0 {
+		opts.Quiet = DefaultCoalesceQuiet
+	}
+
+	return &OverlayCoalescer{
+		zones:   s,
+		opts:    opts,
+		pending: map[zoneKey]*coalescedWrite{},
+		writing: map[zoneKey]*zoneWriter{},
+	}
+}
+
+// SetOverlay schedules setting the overlay of the zone with the given ID of
+// the provided home ID, replacing any change of the zone that is still
+// pending. It returns immediately; see ZoneService.SetOverlay for the write.
+func (c *OverlayCoalescer) SetOverlay(homeID HomeID, zoneID ZoneID, overlay *Overlay, opts ...WriteOption) error {
+	return c.enqueue(zoneKey{homeID, zoneID}, overlay, opts)
+}
+
+// DeleteOverlay schedules removing the overlay of the zone with the given ID
+// of the provided home ID, replacing any change of the zone that is still
+// pending. It returns immediately; see ZoneService.DeleteOverlay for the
+// write.
+func (c *OverlayCoalescer) DeleteOverlay(homeID HomeID, zoneID ZoneID, opts ...WriteOption) error {
+	return c.enqueue(zoneKey{homeID, zoneID}, nil, opts)
+}
+
+// Pending returns the number of zones with a pending write.
+func (c *OverlayCoalescer) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.pending)
+}
+
+// Flush writes all pending changes immediately and waits for the writes in
+// progress. It returns a *MultiError holding the errors of the zones it wrote.
+func (c *OverlayCoalescer) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = map[zoneKey]*coalescedWrite{}
+	for _, w := range pending {
+		w.timer.Stop()
+	}
+	c.mu.Unlock()
+
+	errs := &MultiError{}
+	for key, w := range pending {
+		if err := c.write(ctx, key, w); err != nil {
+			errs.Add(fmt.Sprintf("zone %d", key.zoneID), err)
+		}
+	}
+	c.wg.Wait()
+
+	return errs.ErrorOrNil()
+}
+
+// Close flushes the pending changes, see Flush, after which the coalescer no
+// longer accepts changes.
+func (c *OverlayCoalescer) Close(ctx context.Context) error {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	return c.Flush(ctx)
+}
+
+// enqueue replaces the pending write of the zone with the given key and
+// (re)starts its timer.
+func (c *OverlayCoalescer) enqueue(key zoneKey, overlay *Overlay, opts []WriteOption) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ErrCoalescerClosed
+	}
+
+	now := time.Now()
+	w := c.pending[key]
+	if w == nil {
+		w = &coalescedWrite{since: now}
+		w.timer = time.AfterFunc(c.delay(w, now), func() { c.fire(key, w) })
+		c.pending[key] = w
+	} else {
+		w.timer.Reset(c.delay(w, now))
+	}
+	c.seq++
+	w.overlay, w.opts, w.seq = overlay, opts, c.seq
+
+	return nil
+}
+
+// delay returns the time after which the pending write w is written.
+func (c *OverlayCoalescer) delay(w *coalescedWrite, now time.Time) time.Duration {
+	d := c.opts.Quiet
+	if c.opts.MaxDelay > 0 {
+		d = min(d, max(w.since.Add(c.opts.MaxDelay).Sub(now), 0))
+	}
+
+	return d
+}
+
+// fire writes the pending write w of the zone with the given key in the
+// background, unless it was already written.
+func (c *OverlayCoalescer) fire(key zoneKey, w *coalescedWrite) {
+	c.mu.Lock()
+	if c.pending[key] != w {
+		c.mu.Unlock()
+		return
+	}
+	delete(c.pending, key)
+	c.wg.Add(1)
+	c.mu.Unlock()
+
+	defer c.wg.Done()
+
+	if err := c.write(context.Background(), key, w); err != nil && c.opts.OnError != nil {
+		c.opts.OnError(key.homeID, key.zoneID, err)
+	}
+}
+
+// write writes the pending write w of the zone with the given key. The
+// writes of a zone are serialized, and a write is skipped if a later change
+// of the zone was already written.
+func (c *OverlayCoalescer) write(ctx context.Context, key zoneKey, w *coalescedWrite) error {
+	c.mu.Lock()
+	zw := c.writing[key]
+	if zw == nil {
+		zw = &zoneWriter{}
+		c.writing[key] = zw
+	}
+	overlay, opts, seq := w.overlay, w.opts, w.seq
+	c.mu.Unlock()
+
+	zw.Lock()
+	defer zw.Unlock()
+
+	if seq < zw.seq {
+		return nil
+	}
+	zw.seq = seq
+
+	if overlay == nil {
+		return c.zones.DeleteOverlay(ctx, key.homeID, key.zoneID, opts...)
+	}
+
+	_, err := c.zones.SetOverlay(ctx, key.homeID, key.zoneID, overlay, opts...)
+	return err
+}
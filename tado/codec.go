@@ -0,0 +1,59 @@
+package tado
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec encodes and decodes the values the client stores in its Cache, see
+// WithCodec. Processes sharing a Cache, e.g. a collector polling the API and
+// the frontends reading its data, must use the same Codec. The requests to
+// and responses from the Tado API are always JSON.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// JSONCodec is a Codec using encoding/json. It is the default Codec of a
+// Client.
+type JSONCodec struct{}
+
+// Marshal implements the Codec interface.
+func (JSONCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal implements the Codec interface.
+func (JSONCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// GobCodec is a Codec using encoding/gob, which is more compact than JSON
+// but can only be read by Go programs.
+type GobCodec struct{}
+
+// Marshal implements the Codec interface.
+func (GobCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements the Codec interface.
+func (GobCodec) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// WithCodec sets the Codec used to encode the values stored in the cache of
+// the client, e.g. to use msgpack or protobuf between the processes sharing a
+// Cache. By default, JSONCodec is used. The responses cached using WithCache
+// are stored as received from the API.
+func WithCodec(codec Codec) ClientOption {
+	return func(c *Client) {
+		c.codec = codec
+	}
+}
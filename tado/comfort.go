@@ -0,0 +1,149 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// ComfortScore is a 0–100 score of the comfort of a home, combining how close
+// its rooms are to their target temperature, their humidity and the
+// freshness of the air.
+type ComfortScore struct {
+	Score     int           `json:"score"`
+	Freshness string        `json:"freshness,omitempty"`
+	Rooms     []RoomComfort `json:"rooms"`
+}
+
+// RoomComfort is the comfort score of a single room (zone). Deviation is the
+// difference between the measured and the target temperature in degrees
+// Celsius, or nil when the room is switched off.
+type RoomComfort struct {
+	ZoneID    ZoneID   `json:"zoneId"`
+	Name      string   `json:"name"`
+	Score     int      `json:"score"`
+	Deviation *float64 `json:"deviation,omitempty"`
+	Humidity  float64  `json:"humidity"`
+}
+
+// freshnessScores are the scores of the freshness values of AirComfort.
+var freshnessScores = map[string]float64{
+	"FRESH": 100,
+	"FAIR":  60,
+	"STALE": 20,
+}
+
+// ComfortScore returns the comfort score of the home with the given ID.
+//
+// Each heating room is scored on its deviation from the target temperature,
+// losing 25 points per degree, and on its humidity, losing 5 points per
+// percent outside of 40–60%. Rooms that are switched off are only scored on
+// their humidity, and rooms without measurements score 100. The score of the
+// home is the mean of the rooms, weighted 80/20 with the freshness of the air
+// if the home supports air comfort.
+//
+// If the state of some rooms cannot be retrieved, the score of the other
+// rooms is returned together with a *MultiError.
+func (s *HomeService) ComfortScore(ctx context.Context, homeID HomeID) (*ComfortScore, error) {
+	zones, err := (*ZoneService)(s).List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities, err := s.client.Capabilities(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	score := &ComfortScore{Rooms: []RoomComfort{}}
+	if capabilities.AirComfort {
+		airComfort, err := s.GetAirComfort(ctx, homeID)
+		if err != nil {
+			return nil, err
+		}
+		score.Freshness = airComfort.Freshness.Value
+	}
+
+	// if the states of all zones cannot be retrieved at once, the state of
+	// every zone is requested separately
+	states, statesErr := (*ZoneService)(s).States(ctx, homeID)
+
+	errs := &MultiError{}
+	var total float64
+	for _, zone := range zones {
+		if zone.Type != ZoneTypeHeating {
+			continue
+		}
+
+		state, ok := states[zone.ID]
+		if statesErr != nil {
+			state, err = (*ZoneService)(s).GetState(ctx, homeID, zone.ID)
+			if err != nil {
+				errs.Add(fmt.Sprintf("zone %d", zone.ID), err)
+				continue
+			}
+		} else if !ok {
+			errs.Add(fmt.Sprintf("zone %d", zone.ID), errNoZoneState)
+			continue
+		}
+
+		room, value := roomComfort(zone, state)
+		score.Rooms = append(score.Rooms, room)
+		total += value
+	}
+
+	if n := len(score.Rooms); n > 0 {
+		value := total / float64(n)
+		if freshness, ok := freshnessScores[score.Freshness]; ok {
+			value = 0.8*value + 0.2*freshness
+		}
+		score.Score = int(math.Round(value))
+	}
+
+	return score, errs.ErrorOrNil()
+}
+
+// roomComfort returns the comfort of the given zone and its state, together
+// with its unrounded score.
+func roomComfort(zone Zone, state *ZoneState) (RoomComfort, float64) {
+	room := RoomComfort{ZoneID: zone.ID, Name: zone.Name}
+
+	value := 100.0
+	h := state.SensorDataPoints.Humidity
+	if h != nil {
+		room.Humidity = h.Percentage
+		value = humidityScore(h.Percentage)
+	}
+
+	t := state.SensorDataPoints.InsideTemperature
+	if t != nil && state.Setting.Power == PowerOn && state.Setting.Temperature != nil {
+		deviation := t.Celsius - state.Setting.Temperature.Celsius
+		room.Deviation = &deviation
+
+		value = clampScore(100 - 25*math.Abs(deviation))
+		if h != nil {
+			value = 0.6*value + 0.4*humidityScore(h.Percentage)
+		}
+	}
+
+	room.Score = int(math.Round(value))
+
+	return room, value
+}
+
+// humidityScore returns the score of the given relative humidity in percent.
+func humidityScore(humidity float64) float64 {
+	switch {
+	case humidity < 40:
+		return clampScore(100 - 5*(40-humidity))
+	case humidity > 60:
+		return clampScore(100 - 5*(humidity-60))
+	default:
+		return 100
+	}
+}
+
+// clampScore clamps a score to the range 0–100.
+func clampScore(score float64) float64 {
+	return math.Max(0, math.Min(100, score))
+}
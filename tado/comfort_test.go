@@ -0,0 +1,183 @@
+package tado_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/idriesalbender/go-tado/tado"
+	"github.com/idriesalbender/go-tado/tadotest"
+)
+
+// comfortState returns the state of a heating zone measuring the given
+// temperature in degrees Celsius and humidity in percent, heating to target
+// degrees Celsius or switched off if target is zero.
+func comfortState(celsius, target, humidity float64) tado.ZoneState {
+	var state tado.ZoneState
+	state.Setting = tado.OffSetting(tado.ZoneTypeHeating)
+	if target != 0 {
+		temperature := tado.Celsius(target)
+		state.Setting = tado.ZoneSetting{Type: tado.ZoneTypeHeating, Power: tado.PowerOn, Temperature: &temperature}
+	}
+	state.SensorDataPoints.InsideTemperature = &tado.TemperatureDataPoint{Temperature: tado.Celsius(celsius)}
+	state.SensorDataPoints.Humidity = &tado.PercentageDataPoint{Percentage: humidity}
+	return state
+}
+
+// newComfortServer returns a fake server seeded with a home with the ID 1 and
+// a heating zone with the given state for every state, with IDs starting at
+// 1, and a hot water zone.
+func newComfortServer(airComfort bool, states ...tado.ZoneState) *tadotest.Server {
+	zones := []tadotest.Zone{
+		{Zone: tado.Zone{ID: 10, Name: "Hot water", Type: tado.ZoneTypeHotWater}},
+	}
+	for i, state := range states {
+		id := tado.ZoneID(i + 1)
+		zones = append(zones, tadotest.Zone{
+			Zone:  tado.Zone{ID: id, Name: "Room", Type: tado.ZoneTypeHeating},
+			State: state,
+		})
+	}
+
+	srv := tadotest.NewServer()
+	srv.AddHome(tadotest.Home{
+		Home:  tado.Home{ID: 1, Name: "Home", IsAirComfortEligible: airComfort},
+		Zones: zones,
+	})
+
+	return srv
+}
+
+func deviation(v float64) *float64 {
+	return &v
+}
+
+func TestHomeService_ComfortScore(t *testing.T) {
+	tests := []struct {
+		name      string
+		states    []tado.ZoneState
+		freshness string
+		want      tado.ComfortScore
+	}{
+		{
+			name:   "on target",
+			states: []tado.ZoneState{comfortState(21, 21, 50)},
+			want: tado.ComfortScore{Score: 100, Rooms: []tado.RoomComfort{
+				{ZoneID: 1, Name: "Room", Score: 100, Deviation: deviation(0), Humidity: 50},
+			}},
+		},
+		{
+			name:   "too warm and humid",
+			states: []tado.ZoneState{comfortState(22, 21, 70)},
+			want: tado.ComfortScore{Score: 65, Rooms: []tado.RoomComfort{
+				{ZoneID: 1, Name: "Room", Score: 65, Deviation: deviation(1), Humidity: 70},
+			}},
+		},
+		{
+			name:   "far below target",
+			states: []tado.ZoneState{comfortState(15, 20, 50)},
+			want: tado.ComfortScore{Score: 40, Rooms: []tado.RoomComfort{
+				{ZoneID: 1, Name: "Room", Score: 40, Deviation: deviation(-5), Humidity: 50},
+			}},
+		},
+		{
+			name:   "switched off and dry",
+			states: []tado.ZoneState{comfortState(15, 0, 30)},
+			want: tado.ComfortScore{Score: 50, Rooms: []tado.RoomComfort{
+				{ZoneID: 1, Name: "Room", Score: 50, Humidity: 30},
+			}},
+		},
+		{
+			name:   "without measurements",
+			states: []tado.ZoneState{{}},
+			want: tado.ComfortScore{Score: 100, Rooms: []tado.RoomComfort{
+				{ZoneID: 1, Name: "Room", Score: 100},
+			}},
+		},
+		{
+			name:   "mean of the rooms",
+			states: []tado.ZoneState{comfortState(22, 21, 70), comfortState(20, 20, 45)},
+			want: tado.ComfortScore{Score: 83, Rooms: []tado.RoomComfort{
+				{ZoneID: 1, Name: "Room", Score: 65, Deviation: deviation(1), Humidity: 70},
+				{ZoneID: 2, Name: "Room", Score: 100, Deviation: deviation(0), Humidity: 45},
+			}},
+		},
+		{
+			name:      "with stale air",
+			states:    []tado.ZoneState{comfortState(21, 21, 50)},
+			freshness: "STALE",
+			want: tado.ComfortScore{Score: 84, Freshness: "STALE", Rooms: []tado.RoomComfort{
+				{ZoneID: 1, Name: "Room", Score: 100, Deviation: deviation(0), Humidity: 50},
+			}},
+		},
+		{
+			name: "without heating rooms",
+			want: tado.ComfortScore{Rooms: []tado.RoomComfort{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newComfortServer(tt.freshness != "", tt.states...)
+			defer srv.Close()
+
+			if tt.freshness != "" {
+				srv.Handle("GET /homes/1/airComfort", tadotest.JSON(map[string]any{
+					"freshness": map[string]any{"value": tt.freshness},
+				}))
+			}
+
+			got, err := srv.Client().Home.ComfortScore(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("got %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHomeService_ComfortScore_perZone(t *testing.T) {
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	t.Run("without zone states", func(t *testing.T) {
+		srv := newComfortServer(false, comfortState(22, 21, 70), comfortState(20, 20, 45))
+		defer srv.Close()
+		srv.Handle("GET /homes/1/zoneStates", notFound)
+
+		got, err := srv.Client().Home.ComfortScore(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got.Score != 83 || len(got.Rooms) != 2 {
+			t.Errorf("got %+v, want a score of 83 for 2 rooms", *got)
+		}
+		if n := len(srv.RequestsTo("GET", "/homes/1/zones/1/state")); n != 1 {
+			t.Errorf("requested the state of zone 1 %d times, want 1", n)
+		}
+	})
+
+	t.Run("with a failing zone", func(t *testing.T) {
+		srv := newComfortServer(false, comfortState(22, 21, 70), comfortState(20, 20, 45))
+		defer srv.Close()
+		srv.Handle("GET /homes/1/zoneStates", notFound)
+		srv.Handle("GET /homes/1/zones/2/state", notFound)
+
+		got, err := srv.Client().Home.ComfortScore(context.Background(), 1)
+
+		var multi *tado.MultiError
+		if !errors.As(err, &multi) || len(multi.Errors) != 1 || multi.Errors[0].Target != "zone 2" {
+			t.Fatalf("got error %v, want a *MultiError for zone 2", err)
+		}
+		if got.Score != 65 || len(got.Rooms) != 1 || got.Rooms[0].ZoneID != 1 {
+			t.Errorf("got %+v, want a score of 65 for zone 1", *got)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package tado
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// WithMaxConcurrentRequests limits the number of requests the client has in
+// flight at the same time to n, so that naive callers fetching all zones in
+// parallel do not get the account temporarily blocked. Further requests wait
+// until a request completes. A limit of zero or less disables the limit.
+//
+// The limit is enforced in Do and complements WithRateLimit, which limits the
+// rate rather than the number of requests in flight.
+func WithMaxConcurrentRequests(n int) ClientOption {
+	return func(c *Client) {
+		c.concurrencyLimiter().global = newSemaphore(n)
+	}
+}
+
+// WithMaxConcurrentRequestsPerHome limits the number of requests the client
+// has in flight at the same time for each home to n, in addition to the limit
+// set with WithMaxConcurrentRequests. A limit of zero or less disables the
+// limit.
+func WithMaxConcurrentRequestsPerHome(n int) ClientOption {
+	return func(c *Client) {
+		c.concurrencyLimiter().perHome = n
+	}
+}
+
+// concurrencyLimiter returns the concurrency limiter of the client, creating
+// it if needed.
+func (c *Client) concurrencyLimiter() *concurrencyLimiter {
+	if c.concurrency == nil {
+		c.concurrency = &concurrencyLimiter{homes: make(map[HomeID]semaphore)}
+	}
+
+	return c.concurrency
+}
+
+// semaphore limits the number of concurrent holders. A nil semaphore has no
+// limit.
+type semaphore chan struct{}
+
+// newSemaphore returns a semaphore with n slots, or nil if n is zero or less.
+func newSemaphore(n int) semaphore {
+	if n <= 0 {
+		return nil
+	}
+
+	return make(semaphore, n)
+}
+
+// acquire acquires a slot of the semaphore, or returns the error of ctx if it
+// is done first.
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release releases a slot acquired with acquire.
+func (s semaphore) release() {
+	if s != nil {
+		<-s
+	}
+}
+
+// concurrencyLimiter limits the number of requests in flight, both globally
+// and per home.
+type concurrencyLimiter struct {
+	global  semaphore
+	perHome int
+
+	mu    sync.Mutex
+	homes map[HomeID]semaphore
+}
+
+// homePathPattern matches the home ID in the path of a request.
+var homePathPattern = regexp.MustCompile(`/homes/(\d+)(/|$)`)
+
+// acquire waits until the request may be sent, and returns a function that
+// must be called when the request completes.
+func (l *concurrencyLimiter) acquire(ctx context.Context, req *http.Request) (func(), error) {
+	home := l.home(req)
+	if err := home.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := l.global.acquire(ctx); err != nil {
+		home.release()
+		return nil, err
+	}
+
+	return func() {
+		l.global.release()
+		home.release()
+	}, nil
+}
+
+// home returns the semaphore of the home the request is about, or nil if
+// there is no per-home limit or the request is not about a home.
+func (l *concurrencyLimiter) home(req *http.Request) semaphore {
+	if l.perHome <= 0 {
+		return nil
+	}
+
+	m := homePathPattern.FindStringSubmatch(req.URL.Path)
+	if m == nil {
+		return nil
+	}
+
+	id, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	s, ok := l.homes[HomeID(id)]
+	if !ok {
+		s = newSemaphore(l.perHome)
+		l.homes[HomeID(id)] = s
+	}
+
+	return s
+}
@@ -0,0 +1,149 @@
+package tado
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Config is the configuration of an application built on this library. It can
+// be loaded from a JSON file and/or the environment using LoadConfig.
+type Config struct {
+	// HomeID is the ID of the home the application operates on (TADO_HOME_ID).
+	HomeID HomeID `json:"homeId,omitempty"`
+
+	// BaseURL overrides the base URL of the Tado API (TADO_BASE_URL).
+	BaseURL string `json:"baseUrl,omitempty"`
+
+	// UserAgent overrides the User-Agent header (TADO_USER_AGENT).
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// TokenFile is the path of the file the OAuth2 token is stored in
+	// (TADO_TOKEN_FILE), so that it is reused between runs.
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// RateLimit is the maximum number of requests per second
+	// (TADO_RATE_LIMIT). Zero disables rate limiting.
+	RateLimit float64 `json:"rateLimit,omitempty"`
+
+	// RateBurst is the maximum burst of requests (TADO_RATE_BURST). It
+	// defaults to 1 if RateLimit is set.
+	RateBurst int `json:"rateBurst,omitempty"`
+
+	// RateLimitFile is the path of a file used to share the rate limit with
+	// other processes on the host (TADO_RATE_LIMIT_FILE), see FileLimiter.
+	RateLimitFile string `json:"rateLimitFile,omitempty"`
+}
+
+// LoadConfig loads the configuration from the JSON file at path, if path is
+// not empty, and then applies the TADO_* environment variables on top of it.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(data, config); err != nil {
+			return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	}
+
+	if err := config.applyEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// applyEnv overrides the configuration with the TADO_* environment variables
+// returned by lookup.
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	if v, ok := lookup("TADO_HOME_ID"); ok {
+		id, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("invalid TADO_HOME_ID %q: %w", v, err)
+		}
+		c.HomeID = HomeID(id)
+	}
+
+	if v, ok := lookup("TADO_BASE_URL"); ok {
+		c.BaseURL = v
+	}
+
+	if v, ok := lookup("TADO_USER_AGENT"); ok {
+		c.UserAgent = v
+	}
+
+	if v, ok := lookup("TADO_RATE_LIMIT_FILE"); ok {
+		c.RateLimitFile = v
+	}
+
+	if v, ok := lookup("TADO_TOKEN_FILE"); ok {
+		c.TokenFile = v
+	}
+
+	if v, ok := lookup("TADO_RATE_LIMIT"); ok {
+		rps, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return fmt.Errorf("invalid TADO_RATE_LIMIT %q: %w", v, err)
+		}
+		c.RateLimit = rps
+	}
+
+	if v, ok := lookup("TADO_RATE_BURST"); ok {
+		burst, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return fmt.Errorf("invalid TADO_RATE_BURST %q: %w", v, err)
+		}
+		c.RateBurst = burst
+	}
+
+	return nil
+}
+
+// Options returns the ClientOptions corresponding to the configuration.
+func (c *Config) Options() ([]ClientOption, error) {
+	var opts []ClientOption
+
+	if c.BaseURL != "" {
+		baseURL := c.BaseURL
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+
+		if _, err := url.Parse(baseURL); err != nil {
+			return nil, fmt.Errorf("invalid base URL %q: %w", c.BaseURL, err)
+		}
+
+		opts = append(opts, WithBaseURL(baseURL))
+	}
+
+	if c.UserAgent != "" {
+		opts = append(opts, WithUserAgent(c.UserAgent))
+	}
+
+	if c.TokenFile != "" {
+		store := NewFileTokenStore(c.TokenFile)
+		opts = append(opts, WithAuthenticator(NewDeviceAuthenticator(nil, WithTokenStore(store))))
+	}
+
+	if c.RateLimit > 0 {
+		burst := c.RateBurst
+		if burst <= 0 {
+			burst = 1
+		}
+		if c.RateLimitFile != "" {
+			opts = append(opts, WithSharedRateLimit(c.RateLimitFile, c.RateLimit, burst))
+		} else {
+			opts = append(opts, WithRateLimit(c.RateLimit, burst))
+		}
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,170 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// ACMode represents the mode of an air conditioning zone.
+type ACMode string
+
+// FanLevel represents the fan level of an air conditioning zone.
+type FanLevel string
+
+// Swing represents the vertical or horizontal swing of the louvres of an air
+// conditioning unit. Units with adjustable louvres support positions beyond
+// on and off, see ACModeCapabilities.
+type Swing string
+
+// Light represents the display light of an air conditioning unit.
+type Light string
+
+const (
+	ACModeCool ACMode = "COOL"
+	ACModeHeat ACMode = "HEAT"
+	ACModeDry  ACMode = "DRY"
+	ACModeFan  ACMode = "FAN"
+	ACModeAuto ACMode = "AUTO"
+)
+
+const (
+	FanLevelSilent FanLevel = "SILENT"
+	FanLevel1      FanLevel = "LEVEL1"
+	FanLevel2      FanLevel = "LEVEL2"
+	FanLevel3      FanLevel = "LEVEL3"
+	FanLevel4      FanLevel = "LEVEL4"
+	FanLevel5      FanLevel = "LEVEL5"
+	FanLevelAuto   FanLevel = "AUTO"
+)
+
+const (
+	SwingOn  Swing = "ON"
+	SwingOff Swing = "OFF"
+)
+
+const (
+	LightOn  Light = "ON"
+	LightOff Light = "OFF"
+)
+
+// CoolingSetting is the setting of an air conditioning zone. The zero values
+// of the optional fields leave the corresponding setting to the unit.
+// Temperature is nil when the zone is switched off, and in modes without a
+// temperature, such as FAN.
+type CoolingSetting struct {
+	Power           Power
+	Mode            ACMode
+	Temperature     *Temperature
+	FanLevel        FanLevel
+	VerticalSwing   Swing
+	HorizontalSwing Swing
+	Light           Light
+}
+
+// ZoneSetting returns the ZoneSetting corresponding to the cooling setting.
+func (s CoolingSetting) ZoneSetting() ZoneSetting {
+	if s.Power != PowerOn {
+		return OffSetting(ZoneTypeAirConditioning)
+	}
+
+	setting := ZoneSetting{
+		Type:            ZoneTypeAirConditioning,
+		Power:           PowerOn,
+		Mode:            s.Mode,
+		FanLevel:        s.FanLevel,
+		VerticalSwing:   s.VerticalSwing,
+		HorizontalSwing: s.HorizontalSwing,
+		Light:           s.Light,
+	}
+	if s.Temperature != nil {
+		t := *s.Temperature
+		setting.Temperature = &t
+	}
+
+	return setting
+}
+
+// Cooling returns the setting as a CoolingSetting, and whether it is the
+// setting of an air conditioning zone.
+func (s ZoneSetting) Cooling() (CoolingSetting, bool) {
+	if s.Type != ZoneTypeAirConditioning {
+		return CoolingSetting{}, false
+	}
+
+	return CoolingSetting{
+		Power:           s.Power,
+		Mode:            s.Mode,
+		Temperature:     s.Temperature,
+		FanLevel:        s.FanLevel,
+		VerticalSwing:   s.VerticalSwing,
+		HorizontalSwing: s.HorizontalSwing,
+		Light:           s.Light,
+	}, true
+}
+
+// Validate returns an error wrapping ErrUnsupportedSetting if the setting is
+// not supported by a zone with the given capabilities.
+func (s CoolingSetting) Validate(capabilities *ZoneCapabilities) error {
+	if s.Power != PowerOn {
+		return nil
+	}
+
+	mode, ok := capabilities.Mode(s.Mode)
+	if !ok {
+		return fmt.Errorf("%w: mode %q", ErrUnsupportedSetting, s.Mode)
+	}
+
+	if s.Temperature != nil {
+		if mode.Temperatures == nil {
+			return fmt.Errorf("%w: mode %s has no temperature", ErrUnsupportedSetting, s.Mode)
+		}
+		if err := mode.Temperatures.validate(*s.Temperature); err != nil {
+			return err
+		}
+	}
+
+	if err := validateOption("fan level", s.FanLevel, mode.FanLevel); err != nil {
+		return err
+	}
+	if err := validateOption("vertical swing", s.VerticalSwing, mode.VerticalSwing); err != nil {
+		return err
+	}
+	if err := validateOption("horizontal swing", s.HorizontalSwing, mode.HorizontalSwing); err != nil {
+		return err
+	}
+
+	return validateOption("light", s.Light, mode.Light)
+}
+
+// validateOption returns an error wrapping ErrUnsupportedSetting if value is
+// set and not one of the supported values.
+func validateOption[T ~string](name string, value T, supported []T) error {
+	if value == "" || slices.Contains(supported, value) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s %q", ErrUnsupportedSetting, name, value)
+}
+
+// SetCooling sets an air conditioning overlay with the given setting and
+// termination on the zone with the given ID of the provided home ID, after
+// validating the setting against the capabilities of the zone.
+//
+// Example usage:
+//
+//	t := tado.NewTemperature(22, tado.Celsius)
+//	setting := tado.CoolingSetting{Power: tado.PowerOn, Mode: tado.ACModeCool, Temperature: &t, FanLevel: tado.FanLevelAuto}
+//	_, err := client.Zone.SetCooling(ctx, homeID, zoneID, setting, tado.NextTimeBlockTermination())
+func (s *ZoneService) SetCooling(ctx context.Context, homeID HomeID, zoneID ZoneID, setting CoolingSetting, termination Termination, opts ...WriteOption) (*Overlay, error) {
+	capabilities, err := s.GetCapabilities(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setting.Validate(capabilities); err != nil {
+		return nil, err
+	}
+
+	return s.SetOverlay(ctx, homeID, zoneID, NewOverlay(setting.ZoneSetting(), termination), opts...)
+}
@@ -0,0 +1,51 @@
+package tado
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Date represents a calendar date, encoded as "YYYY-MM-DD" in JSON.
+type Date struct {
+	time.Time
+}
+
+// NewDate returns the Date of t.
+func NewDate(t time.Time) Date {
+	return Date{Time: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// String returns the date formatted as "YYYY-MM-DD".
+func (d Date) String() string {
+	return d.Format(time.DateOnly)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d Date) MarshalJSON() ([]byte, error) {
+	if d.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s *string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	if s == nil || *s == "" {
+		*d = Date{}
+		return nil
+	}
+
+	t, err := time.Parse(time.DateOnly, *s)
+	if err != nil {
+		return err
+	}
+
+	*d = Date{Time: t}
+	return nil
+}
@@ -0,0 +1,127 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+)
+
+// CallForHeat represents the intensity with which a zone called for heat.
+type CallForHeat string
+
+const (
+	CallForHeatNone   CallForHeat = "NONE"
+	CallForHeatLow    CallForHeat = "LOW"
+	CallForHeatMedium CallForHeat = "MEDIUM"
+	CallForHeatHigh   CallForHeat = "HIGH"
+)
+
+// DataInterval is a value of a day report time series that applies during an
+// interval.
+type DataInterval[T any] struct {
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+	Value T         `json:"value"`
+}
+
+// DataPoint is a value of a day report time series measured at a point in
+// time.
+type DataPoint[T any] struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     T         `json:"value"`
+}
+
+// IntervalSeries is a day report time series of intervals.
+type IntervalSeries[T any] struct {
+	TimeSeriesType string            `json:"timeSeriesType"`
+	ValueType      string            `json:"valueType"`
+	DataIntervals  []DataInterval[T] `json:"dataIntervals"`
+}
+
+// PointSeries is a day report time series of data points.
+type PointSeries[T any] struct {
+	TimeSeriesType string         `json:"timeSeriesType"`
+	ValueType      string         `json:"valueType"`
+	Min            T              `json:"min"`
+	Max            T              `json:"max"`
+	DataPoints     []DataPoint[T] `json:"dataPoints"`
+}
+
+// Stripe represents the mode of a zone during an interval of a day report.
+type Stripe struct {
+	StripeType string       `json:"stripeType"`
+	Setting    *ZoneSetting `json:"setting,omitempty"`
+}
+
+// WeatherCondition represents the weather during an interval or slot of a day
+// report.
+type WeatherCondition struct {
+	State       string      `json:"state"`
+	Temperature Temperature `json:"temperature"`
+}
+
+// DayReport represents the historic data of a zone for a single day.
+type DayReport struct {
+	ZoneType ZoneType `json:"zoneType"`
+	Interval struct {
+		From time.Time `json:"from"`
+		To   time.Time `json:"to"`
+	} `json:"interval"`
+	HoursInDay   int `json:"hoursInDay"`
+	MeasuredData struct {
+		MeasuringDeviceConnected IntervalSeries[bool]     `json:"measuringDeviceConnected"`
+		InsideTemperature        PointSeries[Temperature] `json:"insideTemperature"`
+		Humidity                 struct {
+			PointSeries[float64]
+			PercentageUnit string `json:"percentageUnit"`
+		} `json:"humidity"`
+	} `json:"measuredData"`
+	Stripes            IntervalSeries[Stripe]       `json:"stripes"`
+	Settings           IntervalSeries[ZoneSetting]  `json:"settings"`
+	CallForHeat        *IntervalSeries[CallForHeat] `json:"callForHeat,omitempty"`
+	HotWaterProduction *IntervalSeries[bool]        `json:"hotWaterProduction,omitempty"`
+	Weather            struct {
+		Condition IntervalSeries[WeatherCondition] `json:"condition"`
+		Sunny     IntervalSeries[bool]             `json:"sunny"`
+		Slots     struct {
+			TimeSeriesType string                      `json:"timeSeriesType"`
+			ValueType      string                      `json:"valueType"`
+			Slots          map[string]WeatherCondition `json:"slots"`
+		} `json:"slots"`
+	} `json:"weather"`
+}
+
+// GetDayReport returns the day report of the zone with the given ID of the
+// provided home ID for the day of date.
+func (s *ZoneService) GetDayReport(ctx context.Context, homeID HomeID, zoneID ZoneID, date time.Time) (*DayReport, error) {
+	path := fmt.Sprintf("homes/%d/zones/%d/dayReport?date=%s", homeID, zoneID, url.QueryEscape(date.Format(time.DateOnly)))
+	req, err := s.client.newRequest("ZoneService", "GetDayReport", "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var report *DayReport
+	_, err = s.client.Do(ctx, req, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// DayReports returns an iterator over the day reports of the zone with the
+// given ID of the provided home ID, for every day from from up to and
+// including to. Each report is fetched lazily as the iteration progresses;
+// iteration stops after the first error.
+func (s *ZoneService) DayReports(ctx context.Context, homeID HomeID, zoneID ZoneID, from, to time.Time) iter.Seq2[*DayReport, error] {
+	return func(yield func(*DayReport, error) bool) {
+		for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+			report, err := s.GetDayReport(ctx, homeID, zoneID, date)
+			if !yield(report, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package tado
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"time"
+)
+
+// DebugState is a snapshot of the internal state of a Client, as written by
+// Client.DumpState.
+type DebugState struct {
+	Time          time.Time   `json:"time"`
+	BaseURL       string      `json:"baseUrl"`
+	Token         *TokenState `json:"token,omitempty"`
+	RateLimiter   *LimitState `json:"rateLimiter,omitempty"`
+	CacheKeys     []string    `json:"cacheKeys,omitempty"`
+	Subscriptions []string    `json:"subscriptions"`
+}
+
+// LimitState describes the state of the rate limiter of a Client.
+type LimitState struct {
+	Limit  float64 `json:"limit"`
+	Burst  int     `json:"burst"`
+	Tokens float64 `json:"tokens"`
+}
+
+// TokenState describes the OAuth2 token currently held by a Client, without
+// the token itself.
+type TokenState struct {
+	Type   string    `json:"type"`
+	Expiry time.Time `json:"expiry"`
+	Valid  bool      `json:"valid"`
+	Err    string    `json:"error,omitempty"`
+}
+
+// State returns a snapshot of the internal state of the client. It has no
+// side effects: the token is reported as last seen, without refreshing it,
+// and the rate limiter is inspected without taking a token.
+func (c *Client) State() *DebugState {
+	state := &DebugState{
+		Time:          time.Now(),
+		BaseURL:       c.BaseURL().String(),
+		Subscriptions: []string{},
+	}
+
+	if source := c.tokenSource(); source != nil {
+		token, err := source.last()
+		switch {
+		case err != nil:
+			state.Token = &TokenState{Err: err.Error()}
+		case token != nil:
+			state.Token = &TokenState{
+				Type:   token.Type(),
+				Expiry: token.Expiry,
+				Valid:  token.Valid(),
+			}
+		}
+	}
+
+	if c.limiter != nil {
+		state.RateLimiter = &LimitState{
+			Limit:  float64(c.limiter.Limit()),
+			Burst:  c.limiter.Burst(),
+			Tokens: c.limiter.Tokens(),
+		}
+	}
+
+	if lister, ok := c.cache.(interface{ Keys() []string }); ok {
+		state.CacheKeys = lister.Keys()
+	}
+
+	c.mu.Lock()
+	for name, count := range c.subscriptions {
+		for range count {
+			state.Subscriptions = append(state.Subscriptions, name)
+		}
+	}
+	c.mu.Unlock()
+	sort.Strings(state.Subscriptions)
+
+	return state
+}
+
+// DumpState writes a JSON snapshot of the internal state of the client to w.
+// Tokens are never written, only their type and expiry.
+func (c *Client) DumpState(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c.State())
+}
+
+// DumpStateOnSignal writes the state of the client to w using DumpState every
+// time the process receives one of the given signals (typically
+// syscall.SIGUSR1), until ctx is done.
+func (c *Client) DumpStateOnSignal(ctx context.Context, w io.Writer, sig ...os.Signal) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sig...)
+
+	go func() {
+		defer signal.Stop(ch)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+				_ = c.DumpState(w)
+			}
+		}
+	}()
+}
+
+// trackSubscription registers an active background subscription under name,
+// so that it shows up in the debug state. The returned function unregisters
+// it.
+func (c *Client) trackSubscription(name string) func() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.subscriptions == nil {
+		c.subscriptions = map[string]int{}
+	}
+	c.subscriptions[name]++
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		c.subscriptions[name]--
+		if c.subscriptions[name] <= 0 {
+			delete(c.subscriptions, name)
+		}
+	}
+}
+
+// tokenSource returns the TokenSource of the client, or nil if no token has
+// been acquired yet.
+func (c *Client) tokenSource() *recordingTokenSource {
+	if c.auth == nil {
+		return nil
+	}
+
+	return c.auth.current()
+}
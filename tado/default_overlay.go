@@ -0,0 +1,71 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultOverlay represents the default overlay settings of a zone, i.e. when
+// a manual change made on a device or in the app ends: at the next block of
+// the schedule (NextTimeBlockTermination or TadoModeTermination), after a
+// timer (TimerTermination) or never (ManualTermination).
+type DefaultOverlay struct {
+	TerminationCondition Termination `json:"terminationCondition"`
+}
+
+// writeBody implements the writable interface. It strips the read-only
+// fields of the termination.
+func (d DefaultOverlay) writeBody() any {
+	body := struct {
+		TerminationCondition struct {
+			Type              TerminationType `json:"type"`
+			DurationInSeconds int             `json:"durationInSeconds,omitempty"`
+		} `json:"terminationCondition"`
+	}{}
+	body.TerminationCondition.Type = d.TerminationCondition.Type
+	body.TerminationCondition.DurationInSeconds = d.TerminationCondition.DurationInSeconds
+
+	return &body
+}
+
+// GetDefaultOverlay returns the default overlay settings of the zone with the
+// given ID of the provided home ID.
+func (s *ZoneService) GetDefaultOverlay(ctx context.Context, homeID HomeID, zoneID ZoneID) (*DefaultOverlay, error) {
+	req, err := s.client.newRequest("ZoneService", "GetDefaultOverlay", "GET", fmt.Sprintf("homes/%d/zones/%d/defaultOverlay", homeID, zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultOverlay *DefaultOverlay
+	_, err = s.client.Do(ctx, req, &defaultOverlay)
+	if err != nil {
+		return nil, err
+	}
+
+	return defaultOverlay, nil
+}
+
+// SetDefaultOverlay sets the termination of manual changes of the zone with
+// the given ID of the provided home ID, and returns the default overlay
+// settings as applied by Tado.
+//
+// Example usage:
+//
+//	_, err := client.Zone.SetDefaultOverlay(ctx, homeID, zoneID, tado.TimerTermination(time.Hour))
+func (s *ZoneService) SetDefaultOverlay(ctx context.Context, homeID HomeID, zoneID ZoneID, termination Termination, opts ...WriteOption) (*DefaultOverlay, error) {
+	o := newWriteOptions(opts)
+
+	body := &DefaultOverlay{TerminationCondition: termination}
+	req, err := s.client.newRequest("ZoneService", "SetDefaultOverlay", "PUT", fmt.Sprintf("homes/%d/zones/%d/defaultOverlay", homeID, zoneID), body, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var defaultOverlay *DefaultOverlay
+	_, err = s.client.Do(ctx, req, &defaultOverlay)
+	if err != nil {
+		return nil, err
+	}
+
+	return defaultOverlay, nil
+}
@@ -0,0 +1,277 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+)
+
+// DeviceService handles communication with the device-related methods of the
+// Tado API.
+type DeviceService service
+
+// BatteryState represents the battery state of a Tado device.
+type BatteryState string
+
+// Duty represents a duty of a Tado device within its zone.
+type Duty string
+
+const (
+	BatteryStateNormal BatteryState = "NORMAL"
+	BatteryStateLow    BatteryState = "LOW"
+)
+
+const (
+	DutyZoneUI        Duty = "ZONE_UI"
+	DutyZoneLeader    Duty = "ZONE_LEADER"
+	DutyZoneDriver    Duty = "ZONE_DRIVER"
+	DutyCircuitDriver Duty = "CIRCUIT_DRIVER"
+)
+
+// MountingState represents the mounting state of a Tado radiator valve.
+type MountingState string
+
+const (
+	MountingStateCalibrated       MountingState = "CALIBRATED"
+	MountingStateMounted          MountingState = "MOUNTED"
+	MountingStateUnmounted        MountingState = "UNMOUNTED"
+	MountingStateMountingMode     MountingState = "MOUNTING_MODE"
+	MountingStateNeedsCalibration MountingState = "NEEDS_CALIBRATION"
+	MountingStateCalibrationError MountingState = "CALIBRATION_ERROR"
+)
+
+// Device represents a Tado device, such as a thermostat, radiator valve or
+// bridge.
+type Device struct {
+	DeviceType       string           `json:"deviceType"`
+	SerialNo         DeviceSerial     `json:"serialNo" redact:"true"`
+	ShortSerialNo    string           `json:"shortSerialNo,omitempty" redact:"true"`
+	CurrentFwVersion string           `json:"currentFwVersion,omitempty"`
+	ConnectionState  *ConnectionState `json:"connectionState,omitempty"`
+	Characteristics  struct {
+		Capabilities []string `json:"capabilities"`
+	} `json:"characteristics"`
+	BatteryState  BatteryState `json:"batteryState,omitempty"`
+	Orientation   string       `json:"orientation,omitempty"`
+	Duties        []Duty       `json:"duties,omitempty"`
+	MountingState *struct {
+		Value     MountingState `json:"value"`
+		Timestamp time.Time     `json:"timestamp"`
+	} `json:"mountingState,omitempty"`
+	MountingStateWithError MountingState `json:"mountingStateWithError,omitempty"`
+	ChildLockEnabled       *bool         `json:"childLockEnabled,omitempty"`
+	Matter                 *MatterInfo   `json:"matter,omitempty" redact:"true"`
+	HomeKit                *HomeKitInfo  `json:"homeKit,omitempty" redact:"true"`
+}
+
+// ConnectionState represents the connection state of a Tado device.
+type ConnectionState struct {
+	Value     bool      `json:"value"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// MatterInfo holds the Matter pairing identifiers of a device, where exposed
+// by the API.
+type MatterInfo struct {
+	VendorID      int    `json:"vendorId,omitempty"`
+	ProductID     int    `json:"productId,omitempty"`
+	Discriminator int    `json:"discriminator,omitempty"`
+	PairingCode   string `json:"pairingCode,omitempty"`
+	QRCode        string `json:"qrCode,omitempty"`
+}
+
+// HomeKitInfo holds the HomeKit pairing identifiers of a device, where exposed
+// by the API.
+type HomeKitInfo struct {
+	SetupCode string `json:"setupCode,omitempty"`
+	SetupID   string `json:"setupId,omitempty"`
+	Paired    bool   `json:"paired"`
+}
+
+// MatterInfo returns the Matter pairing identifiers of the device and whether
+// the API exposes them.
+func (d *Device) MatterInfo() (MatterInfo, bool) {
+	if d.Matter == nil {
+		return MatterInfo{}, false
+	}
+
+	return *d.Matter, true
+}
+
+// HomeKitInfo returns the HomeKit pairing identifiers of the device and
+// whether the API exposes them.
+func (d *Device) HomeKitInfo() (HomeKitInfo, bool) {
+	if d.HomeKit == nil {
+		return HomeKitInfo{}, false
+	}
+
+	return *d.HomeKit, true
+}
+
+// IsMountable reports whether the device reports a mounting state, which is
+// the case for radiator valves.
+func (d *Device) IsMountable() bool {
+	return d.MountingState != nil || d.MountingStateWithError != ""
+}
+
+// NeedsRemounting reports whether the device is not (properly) mounted.
+func (d *Device) NeedsRemounting() bool {
+	switch d.mountingState() {
+	case MountingStateUnmounted, MountingStateMountingMode:
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedsCalibration reports whether the device is mounted but not calibrated.
+func (d *Device) NeedsCalibration() bool {
+	switch d.mountingState() {
+	case MountingStateMounted, MountingStateNeedsCalibration, MountingStateCalibrationError:
+		return true
+	default:
+		return false
+	}
+}
+
+// NeedsAttention reports whether the device requires a maintenance action.
+func (d *Device) NeedsAttention() bool {
+	return d.NeedsRemounting() || d.NeedsCalibration()
+}
+
+// mountingState returns the most specific mounting state of the device.
+func (d *Device) mountingState() MountingState {
+	if d.MountingStateWithError != "" {
+		return d.MountingStateWithError
+	}
+
+	if d.MountingState != nil {
+		return d.MountingState.Value
+	}
+
+	return ""
+}
+
+// List returns all devices of the home with the given ID.
+func (s *DeviceService) List(ctx context.Context, homeID HomeID) ([]Device, error) {
+	req, err := s.client.newRequest("DeviceService", "List", "GET", fmt.Sprintf("homes/%d/devices", homeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []Device
+	_, err = s.client.Do(ctx, req, &devices)
+	if err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
+
+// All returns an iterator over all devices of the home with the given ID. The
+// devices are fetched when the iteration starts.
+func (s *DeviceService) All(ctx context.Context, homeID HomeID) iter.Seq2[Device, error] {
+	return seq(func() ([]Device, error) {
+		return s.List(ctx, homeID)
+	})
+}
+
+// Get returns the device with the given serial number.
+func (s *DeviceService) Get(ctx context.Context, serialNo DeviceSerial) (*Device, error) {
+	req, err := s.client.newRequest("DeviceService", "Get", "GET", fmt.Sprintf("devices/%s", url.PathEscape(string(serialNo))), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var device *Device
+	_, err = s.client.Do(ctx, req, &device)
+	if err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// Identify makes the device with the given serial number blink its display.
+func (s *DeviceService) Identify(ctx context.Context, serialNo DeviceSerial, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("DeviceService", "Identify", "POST", fmt.Sprintf("devices/%s/identify", url.PathEscape(string(serialNo))), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetChildLock enables or disables the child lock of the device with the
+// given serial number, which disables its buttons. Device.ChildLockEnabled is
+// nil for devices that do not support a child lock.
+func (s *DeviceService) SetChildLock(ctx context.Context, serialNo DeviceSerial, enabled bool, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("DeviceService", "SetChildLock", "PUT", fmt.Sprintf("devices/%s/childLock", url.PathEscape(string(serialNo))), &map[string]bool{"childLockEnabled": enabled}, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsOnline reports whether the device is connected.
+func (d *Device) IsOnline() bool {
+	return d.ConnectionState != nil && d.ConnectionState.Value
+}
+
+// HasDuty reports whether the device has the given duty.
+func (d *Device) HasDuty(duty Duty) bool {
+	for _, dd := range d.Duties {
+		if dd == duty {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeviceListEntry is an entry of the device list of a home, combining a
+// device with the zone it belongs to, if any.
+type DeviceListEntry struct {
+	Type   string `json:"type"`
+	Device Device `json:"device"`
+	Zone   *struct {
+		Discriminator ZoneID `json:"discriminator"`
+		Duties        []Duty `json:"duties"`
+	} `json:"zone,omitempty"`
+}
+
+// GetDeviceList returns the device list of the home with the given ID, which
+// includes the zone of every device.
+func (s *DeviceService) GetDeviceList(ctx context.Context, homeID HomeID) ([]DeviceListEntry, error) {
+	req, err := s.client.newRequest("DeviceService", "GetDeviceList", "GET", fmt.Sprintf("homes/%d/deviceList", homeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var deviceList struct {
+		Entries []DeviceListEntry `json:"entries"`
+	}
+	_, err = s.client.Do(ctx, req, &deviceList)
+	if err != nil {
+		return nil, err
+	}
+
+	return deviceList.Entries, nil
+}
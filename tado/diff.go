@@ -0,0 +1,85 @@
+package tado
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// FieldChange describes a single field that changed between two versions of a
+// model. Field is the dotted JSON path of the field, e.g.
+// "pushNotifications.awayModeReminder".
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   any    `json:"old"`
+	New   any    `json:"new"`
+}
+
+// Diff compares the JSON representations of before and after and returns the
+// fields that differ, sorted by field path. Nested objects are compared field
+// by field; arrays are compared as a whole.
+func Diff(before, after any) ([]FieldChange, error) {
+	b, err := toJSONValue(before)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := toJSONValue(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FieldChange
+	diffValues("", b, a, &changes)
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes, nil
+}
+
+// toJSONValue converts v into its generic JSON representation.
+func toJSONValue(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+// diffValues appends the changes between the generic JSON values b and a,
+// located at path, to changes.
+func diffValues(path string, b, a any, changes *[]FieldChange) {
+	bm, bok := b.(map[string]any)
+	am, aok := a.(map[string]any)
+	if bok && aok {
+		keys := map[string]struct{}{}
+		for k := range bm {
+			keys[k] = struct{}{}
+		}
+		for k := range am {
+			keys[k] = struct{}{}
+		}
+
+		for k := range keys {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			diffValues(p, bm[k], am[k], changes)
+		}
+
+		return
+	}
+
+	if !reflect.DeepEqual(b, a) {
+		*changes = append(*changes, FieldChange{Field: path, Old: b, New: a})
+	}
+}
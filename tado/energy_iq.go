@@ -0,0 +1,255 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/url"
+	"time"
+)
+
+// DefaultEnergyIQURL is the base URL of the Tado Energy IQ API.
+const DefaultEnergyIQURL = "https://energy-insights.tado.com/api/"
+
+// DefaultEnergySavingsURL is the base URL of the Tado energy savings API.
+const DefaultEnergySavingsURL = "https://energy-bob.tado.com/"
+
+// WithEnergyIQURL sets the base URL of the Tado Energy IQ API, e.g. to point
+// the client at a mock server. A trailing slash is added if missing. By
+// default, DefaultEnergyIQURL is used.
+func WithEnergyIQURL(energyIQURL string) ClientOption {
+	return func(c *Client) {
+		c.energyIQURL = c.parseServiceURL("Energy IQ", energyIQURL)
+	}
+}
+
+// WithEnergySavingsURL sets the base URL of the Tado energy savings API, e.g.
+// to point the client at a mock server. A trailing slash is added if missing.
+// By default, DefaultEnergySavingsURL is used.
+func WithEnergySavingsURL(energySavingsURL string) ClientOption {
+	return func(c *Client) {
+		c.energySavingsURL = c.parseServiceURL("energy savings", energySavingsURL)
+	}
+}
+
+// EnergyIQService handles communication with the Energy IQ methods of the
+// Tado API. Energy IQ is only available for homes with IsEnergyIqEligible set.
+type EnergyIQService service
+
+// energyIQPath returns the URL of the given path of the Energy IQ API.
+func (s *EnergyIQService) energyIQPath(format string, args ...any) string {
+	return s.client.energyIQURL.String() + fmt.Sprintf(format, args...)
+}
+
+// EnergyUnit represents the unit in which energy consumption is measured.
+type EnergyUnit string
+
+const (
+	EnergyUnitKWh         EnergyUnit = "kWh"
+	EnergyUnitCubicMeters EnergyUnit = "m3"
+)
+
+// Tariff represents an energy tariff of a home.
+type Tariff struct {
+	ID            string     `json:"id,omitempty"`
+	TariffInCents float64    `json:"tariffInCents"`
+	Unit          EnergyUnit `json:"unit"`
+	StartDate     Date       `json:"startDate"`
+	EndDate       *Date      `json:"endDate,omitempty"`
+}
+
+// MeterReading represents a reading of the energy meter of a home.
+type MeterReading struct {
+	ID      string `json:"id,omitempty"`
+	HomeID  HomeID `json:"homeId,omitempty"`
+	Date    Date   `json:"date"`
+	Reading int    `json:"reading"`
+}
+
+// Consumption represents the energy consumption of a home during a month.
+type Consumption struct {
+	Currency           string     `json:"currency"`
+	Unit               EnergyUnit `json:"unit"`
+	TariffInfo         *Tariff    `json:"tariffInfo,omitempty"`
+	ConsumptionPerDate []struct {
+		Date        Date    `json:"date"`
+		Consumption float64 `json:"consumption"`
+		CostInCents float64 `json:"costInCents"`
+	} `json:"consumptionPerDate"`
+	Summary struct {
+		Consumption             float64 `json:"consumption"`
+		CostInCents             float64 `json:"costInCents"`
+		AverageDailyConsumption float64 `json:"averageDailyConsumption"`
+		AverageDailyCostInCents float64 `json:"averageDailyCostInCents"`
+	} `json:"summary"`
+}
+
+// SavingsReport represents the energy savings of a home during a month.
+type SavingsReport struct {
+	CoveredInterval struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+	} `json:"coveredInterval"`
+	TotalSavings struct {
+		Value float64 `json:"value"`
+		Unit  string  `json:"unit"`
+	} `json:"totalSavings"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// writeBody implements the writable interface. It strips the read-only ID.
+func (t Tariff) writeBody() any {
+	return &struct {
+		TariffInCents float64    `json:"tariffInCents"`
+		Unit          EnergyUnit `json:"unit"`
+		StartDate     Date       `json:"startDate"`
+		EndDate       *Date      `json:"endDate,omitempty"`
+	}{t.TariffInCents, t.Unit, t.StartDate, t.EndDate}
+}
+
+// writeBody implements the writable interface. It strips the read-only ID and
+// home ID.
+func (r MeterReading) writeBody() any {
+	return &struct {
+		Date    Date `json:"date"`
+		Reading int  `json:"reading"`
+	}{r.Date, r.Reading}
+}
+
+// ListTariffs returns the tariffs of the home with the given ID.
+func (s *EnergyIQService) ListTariffs(ctx context.Context, homeID HomeID) ([]Tariff, error) {
+	req, err := s.client.newRequest("EnergyIQService", "ListTariffs", "GET", s.energyIQPath("homes/%d/tariffs", homeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tariffs []Tariff
+	_, err = s.client.Do(ctx, req, &tariffs)
+	if err != nil {
+		return nil, err
+	}
+
+	return tariffs, nil
+}
+
+// SetTariff adds the given tariff to the home with the given ID, or updates it
+// if its ID is set.
+func (s *EnergyIQService) SetTariff(ctx context.Context, homeID HomeID, tariff Tariff, opts ...WriteOption) (*Tariff, error) {
+	o := newWriteOptions(opts)
+
+	method, path := "POST", s.energyIQPath("homes/%d/tariffs", homeID)
+	if tariff.ID != "" {
+		method, path = "PUT", fmt.Sprintf("%s/%s", path, url.PathEscape(tariff.ID))
+	}
+
+	req, err := s.client.newRequest("EnergyIQService", "SetTariff", method, path, tariff, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var updated *Tariff
+	_, err = s.client.Do(ctx, req, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// ListMeterReadings returns the meter readings of the home with the given ID.
+func (s *EnergyIQService) ListMeterReadings(ctx context.Context, homeID HomeID) ([]MeterReading, error) {
+	req, err := s.client.newRequest("EnergyIQService", "ListMeterReadings", "GET", s.energyIQPath("homes/%d/meterReadings", homeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var meterReadings struct {
+		Readings []MeterReading `json:"readings"`
+	}
+	_, err = s.client.Do(ctx, req, &meterReadings)
+	if err != nil {
+		return nil, err
+	}
+
+	return meterReadings.Readings, nil
+}
+
+// AllMeterReadings returns an iterator over the meter readings of the home
+// with the given ID. The readings are fetched when the iteration starts.
+func (s *EnergyIQService) AllMeterReadings(ctx context.Context, homeID HomeID) iter.Seq2[MeterReading, error] {
+	return seq(func() ([]MeterReading, error) {
+		return s.ListMeterReadings(ctx, homeID)
+	})
+}
+
+// AddMeterReading adds a meter reading to the home with the given ID.
+func (s *EnergyIQService) AddMeterReading(ctx context.Context, homeID HomeID, reading MeterReading, opts ...WriteOption) (*MeterReading, error) {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("EnergyIQService", "AddMeterReading", "POST", s.energyIQPath("homes/%d/meterReadings", homeID), reading, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var added *MeterReading
+	_, err = s.client.Do(ctx, req, &added)
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// DeleteMeterReading deletes the meter reading with the given ID of the home
+// with the given ID.
+func (s *EnergyIQService) DeleteMeterReading(ctx context.Context, homeID HomeID, readingID string, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("EnergyIQService", "DeleteMeterReading", "DELETE", s.energyIQPath("homes/%d/meterReadings/%s", homeID, url.PathEscape(readingID)), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetConsumption returns the energy consumption of the home with the given ID
+// during the month of the given date.
+func (s *EnergyIQService) GetConsumption(ctx context.Context, homeID HomeID, month time.Time) (*Consumption, error) {
+	req, err := s.client.newRequest("EnergyIQService", "GetConsumption", "GET", s.energyIQPath("homes/%d/consumption?month=%s", homeID, month.Format("2006-01")), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var consumption *Consumption
+	_, err = s.client.Do(ctx, req, &consumption)
+	if err != nil {
+		return nil, err
+	}
+
+	return consumption, nil
+}
+
+// GetSavingsReport returns the energy savings report of the home with the
+// given ID for the month of the given date. country is the ISO 3166-1 alpha-3
+// code of the country of the home, e.g. "NLD".
+func (s *EnergyIQService) GetSavingsReport(ctx context.Context, homeID HomeID, month time.Time, country string) (*SavingsReport, error) {
+	path := fmt.Sprintf("%s%d/%s?country=%s", s.client.energySavingsURL, homeID, month.Format("2006-01"), url.QueryEscape(country))
+	req, err := s.client.newRequest("EnergyIQService", "GetSavingsReport", "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var report *SavingsReport
+	_, err = s.client.Do(ctx, req, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
@@ -0,0 +1,213 @@
+package tado
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// APIError is a single error reported by the Tado API.
+type APIError struct {
+	Code  string `json:"code"`
+	Title string `json:"title"`
+}
+
+func (e APIError) Error() string {
+	if e.Title == "" {
+		return e.Code
+	}
+
+	return fmt.Sprintf("%s: %s", e.Code, e.Title)
+}
+
+// ErrorResponse is returned when the Tado API responds with a 4xx or 5xx
+// status code. Errors holds the errors decoded from the response body, if
+// any.
+type ErrorResponse struct {
+	Response *http.Response
+	Errors   []APIError `json:"errors"`
+}
+
+func (e *ErrorResponse) Error() string {
+	msg := fmt.Sprintf("%v %v: %d", e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode)
+	for _, err := range e.Errors {
+		msg += " " + err.Error()
+	}
+
+	return msg
+}
+
+// Is reports whether target is ErrNotModified and the status of the response
+// is 304 Not Modified.
+func (e *ErrorResponse) Is(target error) bool {
+	return target == ErrNotModified && e.Response != nil && e.Response.StatusCode == http.StatusNotModified
+}
+
+// RateLimitError occurs when the Tado API returns 429 Too Many Requests.
+//
+// RetryAfter holds the duration parsed from the Retry-After header, or zero if
+// the header was absent or could not be parsed.
+type RateLimitError struct {
+	*ErrorResponse
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%v %v: %d rate limit exceeded, retry after %v",
+			e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode, e.RetryAfter)
+	}
+
+	return fmt.Sprintf("%v %v: %d rate limit exceeded",
+		e.Response.Request.Method, e.Response.Request.URL, e.Response.StatusCode)
+}
+
+// Unwrap returns the underlying ErrorResponse.
+func (e *RateLimitError) Unwrap() error {
+	return e.ErrorResponse
+}
+
+// CheckResponse checks the API response for errors and returns them if
+// present. A response is considered an error if its status code is outside
+// the 2xx range. The response body is decoded into an ErrorResponse and
+// restored, so that it can still be read by the caller.
+func CheckResponse(r *http.Response) error {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
+		return nil
+	}
+
+	errorResponse := &ErrorResponse{Response: r}
+	data, err := io.ReadAll(r.Body)
+	if err == nil && len(data) > 0 {
+		_ = json.Unmarshal(data, errorResponse)
+	}
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(data))
+
+	if r.StatusCode == http.StatusTooManyRequests {
+		return &RateLimitError{
+			ErrorResponse: errorResponse,
+			RetryAfter:    parseRetryAfter(r.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	return errorResponse
+}
+
+// IsNotFound reports whether err is an ErrorResponse with status 404 Not
+// Found.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsNotModified reports whether err is an ErrorResponse with status 304 Not
+// Modified.
+func IsNotModified(err error) bool {
+	return errors.Is(err, ErrNotModified)
+}
+
+// IsUnauthorized reports whether err is an ErrorResponse with status 401
+// Unauthorized or 403 Forbidden.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized) || hasStatus(err, http.StatusForbidden)
+}
+
+// IsRateLimited reports whether err is a RateLimitError.
+func IsRateLimited(err error) bool {
+	var rateLimitError *RateLimitError
+	return errors.As(err, &rateLimitError)
+}
+
+// hasStatus reports whether err is an ErrorResponse with the given status
+// code.
+func hasStatus(err error, status int) bool {
+	var errorResponse *ErrorResponse
+	return errors.As(err, &errorResponse) && errorResponse.Response.StatusCode == status
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, relative to now.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// TargetError is the error of a single target, such as a zone or device, of
+// an operation on multiple targets.
+type TargetError struct {
+	Target string
+	Err    error
+}
+
+func (e *TargetError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Target, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TargetError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError is returned by operations on multiple targets, such as zones or
+// devices, when some of the targets failed. Such operations still return the
+// results of the targets that succeeded.
+type MultiError struct {
+	Errors []*TargetError
+}
+
+// Add records the error err for the given target.
+func (e *MultiError) Add(target string, err error) {
+	e.Errors = append(e.Errors, &TargetError{Target: target, Err: err})
+}
+
+func (e *MultiError) Error() string {
+	switch len(e.Errors) {
+	case 0:
+		return "no errors"
+	case 1:
+		return e.Errors[0].Error()
+	default:
+		return fmt.Sprintf("%v (and %d more errors)", e.Errors[0], len(e.Errors)-1)
+	}
+}
+
+// Unwrap returns the errors of all failed targets, so that errors.Is and
+// errors.As match any of them.
+func (e *MultiError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// ErrorOrNil returns e if any errors were recorded, or nil otherwise.
+func (e *MultiError) ErrorOrNil() error {
+	if e == nil || len(e.Errors) == 0 {
+		return nil
+	}
+
+	return e
+}
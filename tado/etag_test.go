@@ -0,0 +1,66 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestWithConditionalRequests_perUser(t *testing.T) {
+	cache := NewMemoryCache()
+
+	// newUserClient returns a client of the given user, whose solar intensity
+	// is answered with an ETag, and the If-None-Match headers it sent.
+	newUserClient := func(userID string, solarIntensity int) (*Client, *[]string) {
+		var sent []string
+		etag := fmt.Sprintf("%q", userID)
+
+		client := newTestClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/api/v2/me" {
+				return newTestResponse(req, http.StatusOK, fmt.Sprintf(`{"id":%q}`, userID)), nil
+			}
+
+			sent = append(sent, req.Header.Get("If-None-Match"))
+			if req.Header.Get("If-None-Match") == etag {
+				return newTestResponse(req, http.StatusNotModified, ""), nil
+			}
+
+			res := newTestResponse(req, http.StatusOK, fmt.Sprintf(`{"solarIntensity":{"percentage":%d}}`, solarIntensity))
+			res.Header.Set("ETag", etag)
+			return res, nil
+		}, WithConditionalRequests(), WithCacheStore(cache))
+
+		return client, &sent
+	}
+
+	a, sentByA := newUserClient("a", 10)
+	b, sentByB := newUserClient("b", 20)
+
+	for _, step := range []struct {
+		client *Client
+		want   int
+	}{
+		{a, 10},
+		{b, 20},
+		{a, 10},
+		{b, 20},
+	} {
+		weather, err := step.client.Home.GetWeather(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := weather.SolarIntensity.Percentage; got != step.want {
+			t.Errorf("got solar intensity %d, want %d", got, step.want)
+		}
+	}
+
+	// each client only revalidates the response recorded for its own user
+	if want := []string{"", `"a"`}; !slices.Equal(*sentByA, want) {
+		t.Errorf("got If-None-Match headers %q for user a, want %q", *sentByA, want)
+	}
+	if want := []string{"", `"b"`}; !slices.Equal(*sentByB, want) {
+		t.Errorf("got If-None-Match headers %q for user b, want %q", *sentByB, want)
+	}
+}
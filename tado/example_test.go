@@ -0,0 +1,256 @@
+package tado_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+	"github.com/idriesalbender/go-tado/tadotest"
+)
+
+// newServer returns a fake server seeded with a home with a heating zone
+// containing a thermostat with a low battery, as used by the examples.
+func newServer() *tadotest.Server {
+	srv := tadotest.NewServer()
+
+	zone := tadotest.Zone{
+		Zone: tado.Zone{ID: 1, Name: "Living room", Type: tado.ZoneTypeHeating},
+		State: tado.ZoneState{
+			Setting: tado.HeatingSetting(18),
+		},
+	}
+	zone.State.SensorDataPoints.InsideTemperature = &tado.TemperatureDataPoint{Temperature: tado.Celsius(19.5)}
+	zone.Devices = []tado.Device{{
+		DeviceType:      "VA02",
+		SerialNo:        "VA1234567890",
+		ConnectionState: &tado.ConnectionState{Value: true},
+		BatteryState:    tado.BatteryStateLow,
+	}}
+
+	srv.AddHome(tadotest.Home{
+		Home:  tado.Home{ID: 1, Name: "Home", DateTimeZone: "UTC"},
+		State: tado.State{Presence: tado.PresenceHome},
+		Zones: []tadotest.Zone{zone},
+	})
+
+	return srv
+}
+
+// The client authenticates lazily with its Authenticator when the first
+// request is sent. Outside of tests, the default DeviceAuthenticator asks the
+// user to log in once, and can store the token using WithTokenStore.
+func Example_authentication() {
+	srv := newServer()
+	defer srv.Close()
+
+	client := tado.NewClient(
+		tado.WithBaseURL(srv.URL),
+		tado.WithAuthenticator(srv.Authenticator()),
+	)
+
+	me, err := client.User.Get(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Hello %s!\n", me.Name)
+	// Output: Hello Test User!
+}
+
+func ExampleUserService_Get() {
+	srv := newServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	me, err := client.User.Get(context.Background())
+	if err != nil {
+		panic(err)
+	}
+
+	for _, home := range me.Homes {
+		fmt.Printf("%d: %s\n", home.ID, home.Name)
+	}
+	// Output: 1: Home
+}
+
+func ExampleHomeService_Snapshot() {
+	srv := newServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	snapshot, err := client.Home.Snapshot(context.Background(), 1)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%s is %s\n", snapshot.Home.Name, snapshot.State.Presence)
+	for _, room := range snapshot.Rooms {
+		fmt.Printf("%s: %.1f%s\n", room.Name, room.Temperature, snapshot.Unit.Symbol())
+	}
+	// Output:
+	// Home is HOME
+	// Living room: 19.5°C
+}
+
+func ExampleHomeService_SetPresenceLock() {
+	srv := newServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	if err := client.Home.SetPresenceLock(context.Background(), 1, tado.PresenceAway); err != nil {
+		panic(err)
+	}
+
+	fmt.Println(srv.HomeState(1).Presence)
+	// Output: AWAY
+}
+
+// Setting the temperature of a zone creates an overlay, which ends after the
+// termination or when it is deleted, returning the zone to its schedule.
+func ExampleZoneService_SetTemperature() {
+	srv := newServer()
+	defer srv.Close()
+
+	client := srv.Client()
+	ctx := context.Background()
+
+	overlay, err := client.Zone.SetTemperature(ctx, 1, 1, 21, tado.TimerTermination(30*time.Minute))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("heating to %.1f°C for %ds\n", overlay.Setting.Temperature.Celsius, overlay.Termination.DurationInSeconds)
+
+	if err := client.Zone.DeleteOverlay(ctx, 1, 1); err != nil {
+		panic(err)
+	}
+	fmt.Printf("back to %.1f°C\n", srv.ZoneState(1, 1).Setting.Temperature.Celsius)
+	// Output:
+	// heating to 21.0°C for 1800s
+	// back to 18.0°C
+}
+
+func ExampleZoneService_All() {
+	srv := newServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	for zone, err := range client.Zone.All(context.Background(), 1) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("%d: %s (%s)\n", zone.ID, zone.Name, zone.Type)
+	}
+	// Output: 1: Living room (HEATING)
+}
+
+func ExampleDeviceService_All() {
+	srv := newServer()
+	defer srv.Close()
+
+	client := srv.Client()
+
+	for device, err := range client.Device.All(context.Background(), 1) {
+		if err != nil {
+			panic(err)
+		}
+
+		if device.BatteryState == tado.BatteryStateLow {
+			fmt.Printf("%s (%s) has a low battery\n", device.SerialNo, device.DeviceType)
+		}
+	}
+	// Output: VA1234567890 (VA02) has a low battery
+}
+
+func ExampleMobileDeviceService_All() {
+	srv := newServer()
+	defer srv.Close()
+
+	srv.Handle("GET /homes/1/mobileDevices", tadotest.JSON([]tado.MobileDevice{{ID: 7, Name: "Phone"}}))
+	client := srv.Client()
+
+	for device, err := range client.MobileDevice.All(context.Background(), 1) {
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Printf("%d: %s\n", device.ID, device.Name)
+	}
+	// Output: 7: Phone
+}
+
+func ExampleRoomService_List() {
+	srv := newServer()
+	defer srv.Close()
+
+	var room tado.Room
+	room.ID, room.Name = 1, "Bedroom"
+	room.SensorDataPoints.InsideTemperature = &tado.RoomTemperature{Value: 17.5}
+	srv.Handle("GET /hops/homes/1/rooms", tadotest.JSON([]tado.Room{room}))
+	client := srv.Client()
+
+	rooms, err := client.Room.List(context.Background(), 1)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, room := range rooms {
+		fmt.Printf("%s: %.1f°C\n", room.Name, room.SensorDataPoints.InsideTemperature.Value)
+	}
+	// Output: Bedroom: 17.5°C
+}
+
+func ExampleEnergyIQService_GetConsumption() {
+	srv := newServer()
+	defer srv.Close()
+
+	var consumption tado.Consumption
+	consumption.Currency, consumption.Unit = "EUR", "m3"
+	consumption.Summary.Consumption, consumption.Summary.CostInCents = 42.5, 5100
+	srv.Handle("GET /energy-insights/homes/1/consumption", tadotest.JSON(consumption))
+	client := srv.Client()
+
+	month := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	c, err := client.EnergyIQ.GetConsumption(context.Background(), 1, month)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("consumed %.1f %s for %.2f %s\n", c.Summary.Consumption, c.Unit, c.Summary.CostInCents/100, c.Currency)
+	// Output: consumed 42.5 m3 for 51.00 EUR
+}
+
+// The outside temperature history is assembled from the weather of the day
+// reports of the home.
+func ExampleReportService_OutsideTemperatureHistory() {
+	srv := newServer()
+	defer srv.Close()
+
+	srv.Handle("GET /homes/1/zones/1/dayReport", tadotest.JSON(json.RawMessage(`{
+		"interval": {"from": "2026-01-15T00:00:00Z", "to": "2026-01-16T00:00:00Z"},
+		"weather": {"slots": {"slots": {
+			"08:00": {"state": "CLOUDY", "temperature": {"celsius": 2.5}},
+			"16:00": {"state": "SUN", "temperature": {"celsius": 6}}
+		}}}
+	}`)))
+	client := srv.Client()
+
+	day := time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC)
+	history, err := client.Report.OutsideTemperatureHistory(context.Background(), 1, day, day)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, point := range history {
+		fmt.Printf("%s %.1f°C\n", point.Timestamp.Format(time.DateTime), point.Value.Celsius)
+	}
+	// Output:
+	// 2026-01-15 08:00:00 2.5°C
+	// 2026-01-15 16:00:00 6.0°C
+}
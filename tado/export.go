@@ -0,0 +1,177 @@
+package tado
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ExportUserData writes a ZIP archive of all data of the authenticated user
+// that the client can read to w, for users exercising their right to data
+// portability. The archive holds one JSON file per resource:
+//
+//	me.json
+//	homes/<id>/home.json, state.json, weather.json, devices.json, ...
+//	homes/<id>/zones/<id>/state.json, capabilities.json, schedule.json, ...
+//	homes/<id>/rooms.json (Tado X homes)
+//
+// The public API offers no endpoint to export or delete the account itself;
+// a full export including e.g. the history of the account, or the deletion of
+// the account, must be requested from Tado through the app or support.
+//
+// Resources that do not exist for a home, i.e. whose request fails with 404
+// Not Found, are left out. If other resources fail, the archive is still
+// completed, and a *MultiError holding their errors is returned.
+func (c *Client) ExportUserData(ctx context.Context, w io.Writer) error {
+	e := &exporter{zip: zip.NewWriter(w), errs: &MultiError{}, modified: time.Now()}
+
+	me, err := c.User.Get(ctx)
+	if err != nil {
+		return err
+	}
+	if err := e.write("me.json", me); err != nil {
+		return err
+	}
+
+	for _, home := range me.Homes {
+		if err := c.exportHome(ctx, e, home.ID); err != nil {
+			return err
+		}
+	}
+
+	if err := e.zip.Close(); err != nil {
+		return err
+	}
+
+	return e.errs.ErrorOrNil()
+}
+
+// exportHome adds the data of the home with the given ID to the archive.
+func (c *Client) exportHome(ctx context.Context, e *exporter, homeID HomeID) error {
+	dir := fmt.Sprintf("homes/%d/", homeID)
+
+	home, err := c.Home.Get(ctx, homeID)
+	if err != nil {
+		e.errs.Add(fmt.Sprintf("home %d", homeID), err)
+		return nil
+	}
+	if err := e.write(dir+"home.json", home); err != nil {
+		return err
+	}
+
+	for _, f := range []exportFile{
+		{"state.json", func() (any, error) { return c.Home.GetState(ctx, homeID) }},
+		{"weather.json", func() (any, error) { return c.Home.GetWeather(ctx, homeID) }},
+		{"devices.json", func() (any, error) { return c.Device.GetDeviceList(ctx, homeID) }},
+		{"mobile_devices.json", func() (any, error) { return c.MobileDevice.List(ctx, homeID) }},
+		{"invitations.json", func() (any, error) { return c.Home.ListInvitations(ctx, homeID) }},
+		{"notifications.json", func() (any, error) { return c.Home.ListNotifications(ctx, homeID) }},
+		{"incidents.json", func() (any, error) { return c.Home.ListIncidents(ctx, homeID) }},
+		{"heating_system.json", func() (any, error) { return c.Home.GetHeatingSystem(ctx, homeID) }},
+		{"tariffs.json", func() (any, error) { return c.EnergyIQ.ListTariffs(ctx, homeID) }},
+		{"meter_readings.json", func() (any, error) { return c.EnergyIQ.ListMeterReadings(ctx, homeID) }},
+	} {
+		if err := e.add(dir+f.name, f.fetch); err != nil {
+			return err
+		}
+	}
+
+	if home.IsTadoX() {
+		return e.add(dir+"rooms.json", func() (any, error) { return c.Room.List(ctx, homeID) })
+	}
+
+	zones, err := c.Zone.List(ctx, homeID)
+	if err != nil {
+		e.errs.Add(dir+"zones.json", err)
+		return nil
+	}
+	if err := e.write(dir+"zones.json", zones); err != nil {
+		return err
+	}
+
+	for _, zone := range zones {
+		zoneDir := fmt.Sprintf("%szones/%d/", dir, zone.ID)
+
+		for _, f := range []exportFile{
+			{"state.json", func() (any, error) { return c.Zone.GetState(ctx, homeID, zone.ID) }},
+			{"capabilities.json", func() (any, error) { return c.Zone.GetCapabilities(ctx, homeID, zone.ID) }},
+			{"schedule.json", func() (any, error) { return c.exportSchedule(ctx, homeID, zone.ID) }},
+			{"away.json", func() (any, error) { return c.Zone.GetAwayConfiguration(ctx, homeID, zone.ID) }},
+			{"early_start.json", func() (any, error) { return c.Zone.GetEarlyStart(ctx, homeID, zone.ID) }},
+			{"default_overlay.json", func() (any, error) { return c.Zone.GetDefaultOverlay(ctx, homeID, zone.ID) }},
+		} {
+			if err := e.add(zoneDir+f.name, f.fetch); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportedSchedule is the schedule of a zone as exported by ExportUserData.
+type exportedSchedule struct {
+	Timetable *TimetableType  `json:"timetable"`
+	Blocks    []ScheduleBlock `json:"blocks"`
+}
+
+// exportSchedule returns the active timetable of the zone with the given ID
+// and its blocks.
+func (c *Client) exportSchedule(ctx context.Context, homeID HomeID, zoneID ZoneID) (*exportedSchedule, error) {
+	timetable, err := c.Zone.GetActiveTimetable(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := c.Zone.GetScheduleBlocks(ctx, homeID, zoneID, timetable.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exportedSchedule{Timetable: timetable, Blocks: blocks}, nil
+}
+
+// exportFile is a file of the archive of ExportUserData and the function
+// fetching its resource.
+type exportFile struct {
+	name  string
+	fetch func() (any, error)
+}
+
+// exporter writes the files of the archive of ExportUserData.
+type exporter struct {
+	zip      *zip.Writer
+	errs     *MultiError
+	modified time.Time
+}
+
+// add fetches a resource and writes it to the file with the given name.
+// Resources that do not exist are left out, and other errors of fetch are
+// recorded; only errors writing the archive are returned.
+func (e *exporter) add(name string, fetch func() (any, error)) error {
+	v, err := fetch()
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		e.errs.Add(name, err)
+		return nil
+	}
+
+	return e.write(name, v)
+}
+
+// write writes v as indented JSON to the file with the given name.
+func (e *exporter) write(name string, v any) error {
+	f, err := e.zip.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: e.modified})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
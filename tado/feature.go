@@ -0,0 +1,65 @@
+package tado
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Feature is a feature flag of a Tado home, as listed in
+// Home.EnabledFeatures.
+type Feature string
+
+// Enabled reports whether the given feature is enabled for the home.
+func (h *Home) Enabled(feature Feature) bool {
+	return slices.Contains(h.EnabledFeatures, string(feature))
+}
+
+// AppConfiguration is the configuration of the official Tado app for a home,
+// which alternative clients can use to mirror its conditional UI. Flags holds
+// all boolean settings by name, including the ones with a dedicated field,
+// so that flags added by Tado are readable before this package knows them.
+type AppConfiguration struct {
+	HideCriticalNotifications bool            `json:"hideCriticalNotifications"`
+	Flags                     map[string]bool `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *AppConfiguration) UnmarshalJSON(data []byte) error {
+	type appConfiguration AppConfiguration
+	if err := json.Unmarshal(data, (*appConfiguration)(c)); err != nil {
+		return err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	c.Flags = map[string]bool{}
+	for name, v := range fields {
+		if b, ok := v.(bool); ok {
+			c.Flags[name] = b
+		}
+	}
+
+	return nil
+}
+
+// GetAppConfiguration returns the app configuration of the home with the
+// given ID.
+func (s *HomeService) GetAppConfiguration(ctx context.Context, id HomeID) (*AppConfiguration, error) {
+	req, err := s.client.newRequest("HomeService", "GetAppConfiguration", "GET", fmt.Sprintf("homes/%d/appConfiguration", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var config *AppConfiguration
+	_, err = s.client.Do(ctx, req, &config)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,185 @@
+package tado
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// tokenLimiter is a token bucket rate limiter, implemented by *rate.Limiter
+// and *FileLimiter.
+type tokenLimiter interface {
+	Wait(ctx context.Context) error
+	Limit() rate.Limit
+	Burst() int
+	Tokens() float64
+}
+
+// FileLimiter is a token bucket rate limiter whose state is stored in a file
+// and guarded by a file lock, so that several processes on one host
+// collectively respect a single budget, e.g. independent binaries using the
+// same Tado account. File locks are only supported on Unix systems; elsewhere
+// Wait returns an error, and so do the requests of a client using
+// WithSharedRateLimit.
+type FileLimiter struct {
+	path  string
+	limit rate.Limit
+	burst int
+}
+
+// NewFileLimiter returns a FileLimiter storing its state at path, allowing rps
+// requests per second with bursts of up to burst requests. All processes
+// sharing the file must use the same rps and burst. A non-positive rps
+// disables the limit, like rate.Inf, and a burst below 1 is raised to 1.
+func NewFileLimiter(path string, rps float64, burst int) *FileLimiter {
+	limit := rate.Limit(rps)
+	if rps <= 0 {
+		limit = rate.Inf
+	}
+
+	return &FileLimiter{path: path, limit: limit, burst: max(burst, 1)}
+}
+
+// WithSharedRateLimit is like WithRateLimit, but shares the budget with all
+// processes on the host using the same file, see FileLimiter and
+// NewFileLimiter.
+func WithSharedRateLimit(path string, rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = NewFileLimiter(path, rps, burst)
+		c.scheduler = newScheduler(c.limiter)
+	}
+}
+
+// Limit returns the maximum number of requests per second.
+func (l *FileLimiter) Limit() rate.Limit {
+	return l.limit
+}
+
+// Burst returns the maximum burst size.
+func (l *FileLimiter) Burst() int {
+	return l.burst
+}
+
+// Tokens returns the number of tokens currently available, or zero if the
+// state cannot be read. It does not modify the file.
+func (l *FileLimiter) Tokens() float64 {
+	f, err := os.Open(l.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return float64(l.burst)
+	}
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return 0
+	}
+	defer unlockFile(f)
+
+	state, err := l.load(f, time.Now())
+	if err != nil {
+		return 0
+	}
+
+	return state.Tokens
+}
+
+// Wait blocks until a token is available and takes it, or ctx is done.
+func (l *FileLimiter) Wait(ctx context.Context) error {
+	if l.limit == rate.Inf {
+		return nil
+	}
+
+	for {
+		var wait time.Duration
+		err := l.update(func(state *bucketState) {
+			if state.Tokens >= 1 {
+				state.Tokens--
+				return
+			}
+			wait = time.Duration((1 - state.Tokens) / float64(l.limit) * float64(time.Second))
+		})
+		if err != nil {
+			return err
+		}
+		if wait == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// bucketState is the state of a FileLimiter as stored in its file.
+type bucketState struct {
+	Tokens float64   `json:"tokens"`
+	Last   time.Time `json:"last"`
+}
+
+// update locks the file, refills the bucket, calls fn with the state and
+// writes the state back.
+func (l *FileLimiter) update(fn func(state *bucketState)) error {
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening rate limit file: %w", err)
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return fmt.Errorf("locking rate limit file: %w", err)
+	}
+	defer unlockFile(f)
+
+	state, err := l.load(f, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fn(&state)
+
+	data, err := json.Marshal(&state)
+	if err != nil {
+		return err
+	}
+
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("writing rate limit file: %w", err)
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return fmt.Errorf("writing rate limit file: %w", err)
+	}
+
+	return nil
+}
+
+// load reads the state from f, which must be locked, and refills the bucket
+// up to now. An empty or invalid file holds a full bucket.
+func (l *FileLimiter) load(f *os.File, now time.Time) (bucketState, error) {
+	state := bucketState{Tokens: float64(l.burst), Last: now}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return state, fmt.Errorf("reading rate limit file: %w", err)
+	}
+	if len(data) > 0 && json.Unmarshal(data, &state) == nil {
+		if elapsed := now.Sub(state.Last); elapsed > 0 {
+			state.Tokens += elapsed.Seconds() * float64(l.limit)
+		}
+		state.Tokens = min(state.Tokens, float64(l.burst))
+		state.Last = now
+	}
+
+	return state, nil
+}
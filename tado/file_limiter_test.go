@@ -0,0 +1,82 @@
+//go:build unix
+
+package tado
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestFileLimiter_contention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	limiters := []*FileLimiter{
+		NewFileLimiter(path, 1e-9, 5),
+		NewFileLimiter(path, 1e-9, 5),
+	}
+
+	// with a cancelled context, Wait only succeeds if it can take a token at
+	// once
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var mu sync.Mutex
+	granted := 0
+	var wg sync.WaitGroup
+	for i := range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := limiters[i%2].Wait(ctx)
+			switch {
+			case err == nil:
+				mu.Lock()
+				granted++
+				mu.Unlock()
+			case !errors.Is(err, context.Canceled):
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if granted != 5 {
+		t.Errorf("granted %d tokens, want the burst of 5 shared by both limiters", granted)
+	}
+	for i, l := range limiters {
+		if got := l.Tokens(); got >= 1 {
+			t.Errorf("limiter %d has %v tokens left, want none", i, got)
+		}
+	}
+}
+
+func TestFileLimiter_Tokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ratelimit.json")
+	a := NewFileLimiter(path, 1e-9, 2)
+	b := NewFileLimiter(path, 1e-9, 2)
+
+	if got := b.Tokens(); got != 2 {
+		t.Errorf("got %v tokens before the file exists, want 2", got)
+	}
+
+	if err := a.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := b.Tokens(); got < 1 || got >= 2 {
+		t.Errorf("got %v tokens after a token was taken by the other limiter, want 1", got)
+	}
+}
+
+func TestFileLimiter_unlimited(t *testing.T) {
+	l := NewFileLimiter(filepath.Join(t.TempDir(), "ratelimit.json"), 0, 0)
+
+	for range 10 {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
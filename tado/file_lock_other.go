@@ -0,0 +1,18 @@
+//go:build !unix
+
+package tado
+
+import (
+	"errors"
+	"os"
+)
+
+// lockFile is not supported on this platform.
+func lockFile(*os.File) error {
+	return errors.ErrUnsupported
+}
+
+// unlockFile is not supported on this platform.
+func unlockFile(*os.File) error {
+	return errors.ErrUnsupported
+}
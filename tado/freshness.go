@@ -0,0 +1,127 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultAcmeURL is the base URL of the Tado air comfort ("acme") API.
+const DefaultAcmeURL = "https://acme.tado.com/v1/"
+
+// WithAcmeURL sets the base URL of the Tado air comfort API, e.g. to point the
+// client at a mock server. A trailing slash is added if missing. By default,
+// DefaultAcmeURL is used.
+func WithAcmeURL(acmeURL string) ClientOption {
+	return func(c *Client) {
+		c.acmeURL = c.parseServiceURL("acme", acmeURL)
+	}
+}
+
+// FreshnessReport reports how recently the rooms of a home were ventilated.
+// Freshness and LastOpenWindow are the home-level values of AirComfort.
+type FreshnessReport struct {
+	Freshness      string          `json:"freshness,omitempty"`
+	LastOpenWindow *time.Time      `json:"lastOpenWindow,omitempty"`
+	Rooms          []RoomFreshness `json:"rooms"`
+}
+
+// RoomFreshness reports how recently a single room (zone) was ventilated.
+// Freshness is empty and LastOpenWindow is nil when Tado has no data for the
+// room. WindowOpen reports whether an open window is currently detected.
+type RoomFreshness struct {
+	ZoneID         ZoneID     `json:"zoneId"`
+	Name           string     `json:"name"`
+	Freshness      string     `json:"freshness,omitempty"`
+	LastOpenWindow *time.Time `json:"lastOpenWindow,omitempty"`
+	WindowOpen     bool       `json:"windowOpen"`
+}
+
+// roomsAirComfort is the per-room air comfort returned by the acme API.
+type roomsAirComfort struct {
+	Freshness struct {
+		Value          string     `json:"value"`
+		LastOpenWindow *time.Time `json:"lastOpenWindow"`
+	} `json:"freshness"`
+	Rooms []struct {
+		RoomID    ZoneID `json:"roomId"`
+		Freshness struct {
+			Value          string     `json:"value"`
+			LastOpenWindow *time.Time `json:"lastOpenWindow"`
+		} `json:"freshness"`
+	} `json:"rooms"`
+}
+
+// RoomFreshness returns the freshness of the air in the heating rooms of the
+// home with the given ID, e.g. for ventilation coaching. The per-room air
+// comfort of the acme API, where the home supports air comfort, is merged
+// with the open windows detected in the zone states: the most recent open
+// window of either source is reported.
+//
+// If the state of some rooms cannot be retrieved, the freshness of the other
+// rooms is returned together with a *MultiError.
+func (s *HomeService) RoomFreshness(ctx context.Context, homeID HomeID) (*FreshnessReport, error) {
+	zones, err := (*ZoneService)(s).List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities, err := s.client.Capabilities(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FreshnessReport{Rooms: []RoomFreshness{}}
+	acme := map[ZoneID]RoomFreshness{}
+	if capabilities.AirComfort {
+		req, err := s.client.newRequest("HomeService", "RoomFreshness", "GET", s.client.acmeURL.String()+fmt.Sprintf("homes/%d/airComfort", homeID), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var airComfort roomsAirComfort
+		_, err = s.client.Do(ctx, req, &airComfort)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Freshness = airComfort.Freshness.Value
+		report.LastOpenWindow = airComfort.Freshness.LastOpenWindow
+		for _, room := range airComfort.Rooms {
+			acme[room.RoomID] = RoomFreshness{
+				Freshness:      room.Freshness.Value,
+				LastOpenWindow: room.Freshness.LastOpenWindow,
+			}
+		}
+	}
+
+	states, err := (*ZoneService)(s).States(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	errs := &MultiError{}
+	for _, zone := range zones {
+		if zone.Type != ZoneTypeHeating {
+			continue
+		}
+
+		state, ok := states[zone.ID]
+		if !ok {
+			errs.Add(fmt.Sprintf("zone %d", zone.ID), errNoZoneState)
+			continue
+		}
+
+		room := acme[zone.ID]
+		room.ZoneID, room.Name = zone.ID, zone.Name
+		room.WindowOpen = state.OpenWindow != nil || state.OpenWindowDetected
+		if w := state.OpenWindow; w != nil && (room.LastOpenWindow == nil || w.DetectedTime.After(*room.LastOpenWindow)) {
+			detected := w.DetectedTime
+			room.LastOpenWindow = &detected
+		}
+
+		report.Rooms = append(report.Rooms, room)
+	}
+
+	return report, errs.ErrorOrNil()
+}
@@ -0,0 +1,126 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrWrongGeneration is matched by a WrongGenerationError using errors.Is.
+var ErrWrongGeneration = errors.New("wrong home generation")
+
+// WrongGenerationError is returned instead of a 404 Not Found when an
+// endpoint of the classic API is called for a Tado X home, or an endpoint of
+// the Tado X API for a classic home. Tado X homes have rooms instead of zones
+// and are controlled using the RoomService; see Capabilities.TadoX.
+//
+// Example usage:
+//
+//	_, err := client.Zone.List(ctx, homeID)
+//	if errors.Is(err, tado.ErrWrongGeneration) {
+//		rooms, err := client.Room.List(ctx, homeID)
+//		...
+//	}
+type WrongGenerationError struct {
+	HomeID HomeID
+
+	// Generation is the generation of the home, e.g. GenerationLineX.
+	Generation string
+
+	// Service is the name of the service to use for the home instead, e.g.
+	// "RoomService".
+	Service string
+
+	// Err is the underlying ErrorResponse.
+	Err error
+}
+
+func (e *WrongGenerationError) Error() string {
+	generation := e.Generation
+	if generation == "" {
+		generation = "classic"
+	}
+
+	return fmt.Sprintf("home %d is a %s home, use %s instead: %v", e.HomeID, generation, e.Service, e.Err)
+}
+
+// Is reports whether target is ErrWrongGeneration.
+func (e *WrongGenerationError) Is(target error) bool {
+	return target == ErrWrongGeneration
+}
+
+// Unwrap returns the underlying ErrorResponse.
+func (e *WrongGenerationError) Unwrap() error {
+	return e.Err
+}
+
+// classicPathPattern and hopsPathPattern match the paths of the generation
+// specific endpoints of the classic and the Tado X API, and capture the home
+// ID.
+var (
+	classicPathPattern = regexp.MustCompile(`/homes/(\d+)/(zones|zoneStates|devices|deviceList)(/|$)`)
+	hopsPathPattern    = regexp.MustCompile(`/homes/(\d+)/(rooms|quickActions)(/|$)`)
+)
+
+// checkGeneration returns a WrongGenerationError instead of err if err is a
+// 404 Not Found of a request to a generation specific endpoint of a home, i.e.
+// the zones and devices of the classic API or the rooms of the Tado X API,
+// and the endpoint belongs to the API of the other generation than that of
+// the home. err is returned unchanged otherwise, including for the endpoints
+// of other APIs and when the generation of the home cannot be determined.
+func (c *Client) checkGeneration(ctx context.Context, req *http.Request, err error) error {
+	if !hasStatus(err, http.StatusNotFound) {
+		return err
+	}
+
+	u := req.URL.String()
+	var m []string
+	hops := false
+	switch {
+	case strings.HasPrefix(u, c.baseURL.String()):
+		m = classicPathPattern.FindStringSubmatch(req.URL.Path)
+	case strings.HasPrefix(u, c.hopsURL.String()):
+		m = hopsPathPattern.FindStringSubmatch(req.URL.Path)
+		hops = true
+	}
+	if m == nil {
+		return err
+	}
+	id, _ := strconv.Atoi(m[1])
+	homeID := HomeID(id)
+
+	generation, gerr := c.generation(ctx, homeID)
+	if gerr != nil {
+		return err
+	}
+
+	tadoX := generation == GenerationLineX
+	switch {
+	case tadoX && !hops:
+		return &WrongGenerationError{HomeID: homeID, Generation: generation, Service: "RoomService", Err: err}
+	case !tadoX && hops:
+		return &WrongGenerationError{HomeID: homeID, Generation: generation, Service: "ZoneService", Err: err}
+	default:
+		return err
+	}
+}
+
+// generation returns the generation of the home with the given ID, taken from
+// its cached capabilities if available, so that no probes are sent.
+func (c *Client) generation(ctx context.Context, homeID HomeID) (string, error) {
+	var cached Capabilities
+	if c.cacheGet(ctx, fmt.Sprintf("capabilities/%d", homeID), &cached) {
+		return cached.Generation, nil
+	}
+
+	home, err := c.Home.Get(ctx, homeID)
+	if err != nil {
+		return "", err
+	}
+
+	return home.Generation, nil
+}
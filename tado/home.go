@@ -2,6 +2,7 @@ package tado
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -20,50 +21,66 @@ const (
 
 // Home represents a Tado home.
 type Home struct {
-	ID                         int       `json:"id"`
-	Name                       string    `json:"name"`
-	DateTimeZone               string    `json:"dateTimeZone"`
-	DateCreated                time.Time `json:"dateCreated"`
-	TemperatureUnit            string    `json:"temperatureUnit"`
-	Partner                    string    `json:"partner"`
-	SimpleSmartScheduleEnabled bool      `json:"simpleSmartScheduleEnabled"`
-	AwayRadiusInMeters         float64   `json:"awayRadiusInMeters"`
-	InstallationCompleted      bool      `json:"installationCompleted"`
-	IncidentDetection          struct {
-		Supported bool `json:"supported"`
-		Enabled   bool `json:"enabled"`
-	} `json:"incidentDetection"`
-	Generation              string `json:"generation"`
-	ZonesCount              int    `json:"zonesCount"`
-	Language                string `json:"language"`
-	PreventFromSubscribing  bool   `json:"preventFromSubscribing"`
-	Skills                  []any  `json:"skills"`
-	ChristmasModeEnabled    bool   `json:"christmasModeEnabled"`
-	ShowAutoAssistReminders bool   `json:"showAutoAssistReminders"`
-	ContactDetails          struct {
-		Name  string `json:"name"`
-		Email string `json:"email"`
-		Phone string `json:"phone"`
-	} `json:"contactDetails"`
-	Address struct {
-		AddressLine1 string `json:"addressLine1"`
-		AddressLine2 any    `json:"addressLine2"`
-		ZipCode      string `json:"zipCode"`
-		City         string `json:"city"`
-		State        any    `json:"state"`
-		Country      string `json:"country"`
-	} `json:"address"`
-	Geolocation struct {
-		Latitude  float64 `json:"latitude"`
-		Longitude float64 `json:"longitude"`
-	} `json:"geolocation"`
-	ConsentGrantSkippable bool     `json:"consentGrantSkippable"`
-	EnabledFeatures       []string `json:"enabledFeatures"`
-	IsAirComfortEligible  bool     `json:"isAirComfortEligible"`
-	IsBalanceAcEligible   bool     `json:"isBalanceAcEligible"`
-	IsEnergyIqEligible    bool     `json:"isEnergyIqEligible"`
-	IsHeatSourceInstalled bool     `json:"isHeatSourceInstalled"`
-	IsHeatPumpInstalled   bool     `json:"isHeatPumpInstalled"`
+	ID                         HomeID            `json:"id"`
+	Name                       string            `json:"name"`
+	DateTimeZone               string            `json:"dateTimeZone"`
+	DateCreated                time.Time         `json:"dateCreated"`
+	TemperatureUnit            TemperatureUnit   `json:"temperatureUnit"`
+	Partner                    string            `json:"partner"`
+	SimpleSmartScheduleEnabled bool              `json:"simpleSmartScheduleEnabled"`
+	AwayRadiusInMeters         float64           `json:"awayRadiusInMeters"`
+	InstallationCompleted      bool              `json:"installationCompleted"`
+	IncidentDetection          IncidentDetection `json:"incidentDetection"`
+	Generation                 string            `json:"generation"`
+	ZonesCount                 int               `json:"zonesCount"`
+	Language                   string            `json:"language"`
+	PreventFromSubscribing     bool              `json:"preventFromSubscribing"`
+	Skills                     []any             `json:"skills"`
+	ChristmasModeEnabled       bool              `json:"christmasModeEnabled"`
+	ShowAutoAssistReminders    bool              `json:"showAutoAssistReminders"`
+	ContactDetails             ContactDetails    `json:"contactDetails" redact:"true"`
+	Address                    Address           `json:"address" redact:"true"`
+	Geolocation                Geolocation       `json:"geolocation" redact:"true"`
+	ConsentGrantSkippable      bool              `json:"consentGrantSkippable"`
+	EnabledFeatures            []string          `json:"enabledFeatures"`
+	IsAirComfortEligible       bool              `json:"isAirComfortEligible"`
+	IsBalanceAcEligible        bool              `json:"isBalanceAcEligible"`
+	IsEnergyIqEligible         bool              `json:"isEnergyIqEligible"`
+	IsHeatSourceInstalled      bool              `json:"isHeatSourceInstalled"`
+	IsHeatPumpInstalled        bool              `json:"isHeatPumpInstalled"`
+}
+
+// ContactDetails represents the contact details of a Tado home.
+type ContactDetails struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+// Address represents the address of a Tado home.
+type Address struct {
+	AddressLine1 string `json:"addressLine1"`
+	AddressLine2 any    `json:"addressLine2"`
+	ZipCode      string `json:"zipCode"`
+	City         string `json:"city"`
+	State        any    `json:"state"`
+	Country      string `json:"country"`
+}
+
+// Geolocation represents the location of a Tado home, used for geofencing and
+// the weather.
+type Geolocation struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// HomeDetails is an update of the details of a Tado home. Nil fields are left
+// unchanged.
+type HomeDetails struct {
+	Name           *string
+	ContactDetails *ContactDetails
+	Address        *Address
+	Geolocation    *Geolocation
 }
 
 // State represents the state of a Tado home.
@@ -123,26 +140,31 @@ type Weather struct {
 		Percentage int       `json:"percentage"`
 		Timestamp  time.Time `json:"timestamp"`
 	} `json:"solarIntensity"`
-	OutsideTemperature struct {
-		Celsius    float64   `json:"celsius"`
-		Fahrenheit float64   `json:"fahrenheit"`
-		Timestamp  time.Time `json:"timestamp"`
-		Type       string    `json:"type"`
-		Precision  struct {
-			Celsius    float64 `json:"celsius"`
-			Fahrenheit float64 `json:"fahrenheit"`
-		} `json:"precision"`
-	} `json:"outsideTemperature"`
-	WeatherState struct {
+	OutsideTemperature TemperatureDataPoint `json:"outsideTemperature"`
+	WeatherState       struct {
 		Type      string    `json:"type"`
 		Value     string    `json:"value"`
 		Timestamp time.Time `json:"timestamp"`
 	} `json:"weatherState"`
+
+	// Wind and Precipitation are only present in the extended weather that
+	// some regions receive; see WindInfo and PrecipitationInfo.
+	Wind          *WindInfo          `json:"wind,omitempty"`
+	Precipitation *PrecipitationInfo `json:"precipitation,omitempty"`
+
+	// Extended holds the fields of the weather that have no dedicated field,
+	// or that could not be decoded, as raw JSON.
+	Extended map[string]json.RawMessage `json:"-"`
 }
 
 // Get returns the home with the given ID.
-func (s *HomeService) Get(ctx context.Context, id int) (*Home, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d", id), nil)
+func (s *HomeService) Get(ctx context.Context, id HomeID) (*Home, error) {
+	return s.get(ctx, id)
+}
+
+// get implements Get, sending the request with the given options.
+func (s *HomeService) get(ctx context.Context, id HomeID, opts ...RequestOption) (*Home, error) {
+	req, err := s.client.newRequest("HomeService", "Get", "GET", fmt.Sprintf("homes/%d", id), nil, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -157,8 +179,8 @@ func (s *HomeService) Get(ctx context.Context, id int) (*Home, error) {
 }
 
 // GetAirComfort returns the air comfort of the home with the given ID.
-func (s *HomeService) GetAirComfort(ctx context.Context, id int) (*AirComfort, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/airComfort", id), nil)
+func (s *HomeService) GetAirComfort(ctx context.Context, id HomeID) (*AirComfort, error) {
+	req, err := s.client.newRequest("HomeService", "GetAirComfort", "GET", fmt.Sprintf("homes/%d/airComfort", id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -173,8 +195,8 @@ func (s *HomeService) GetAirComfort(ctx context.Context, id int) (*AirComfort, e
 }
 
 // GetHeatSystem returns the heating system of the home with the given ID.
-func (s *HomeService) GetHeatingSystem(ctx context.Context, id int) (*HeatingSystem, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/heatingSystem", id), nil)
+func (s *HomeService) GetHeatingSystem(ctx context.Context, id HomeID) (*HeatingSystem, error) {
+	req, err := s.client.newRequest("HomeService", "GetHeatingSystem", "GET", fmt.Sprintf("homes/%d/heatingSystem", id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -189,8 +211,8 @@ func (s *HomeService) GetHeatingSystem(ctx context.Context, id int) (*HeatingSys
 }
 
 // GetFlowTemperatureOptimization returns the flow temperature optimization of the home with the given ID.
-func (s *HomeService) GetFlowTemperatureOptimization(ctx context.Context, id int) (*FlowTemperatureOptimization, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/flowTemperatureOptimization", id), nil)
+func (s *HomeService) GetFlowTemperatureOptimization(ctx context.Context, id HomeID) (*FlowTemperatureOptimization, error) {
+	req, err := s.client.newRequest("HomeService", "GetFlowTemperatureOptimization", "GET", fmt.Sprintf("homes/%d/flowTemperatureOptimization", id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -204,9 +226,49 @@ func (s *HomeService) GetFlowTemperatureOptimization(ctx context.Context, id int
 	return flowTemperatureOptimization, nil
 }
 
+// SetMaxFlowTemperature sets the maximum flow temperature of the home with the
+// given ID and returns the resulting flow temperature optimization.
+func (s *HomeService) SetMaxFlowTemperature(ctx context.Context, id HomeID, maxFlowTemperature int, opts ...WriteOption) (*FlowTemperatureOptimization, error) {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("HomeService", "SetMaxFlowTemperature", "PATCH", fmt.Sprintf("homes/%d/flowTemperatureOptimization", id), &map[string]int{"maxFlowTemperature": maxFlowTemperature}, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetFlowTemperatureOptimization(ctx, id)
+}
+
+// SetMaxFlowTemperatureWithChanges sets the maximum flow temperature of the
+// home with the given ID, like SetMaxFlowTemperature, and additionally returns
+// the fields that were changed by the update.
+func (s *HomeService) SetMaxFlowTemperatureWithChanges(ctx context.Context, id HomeID, maxFlowTemperature int, opts ...WriteOption) (*FlowTemperatureOptimization, []FieldChange, error) {
+	before, err := s.GetFlowTemperatureOptimization(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	after, err := s.SetMaxFlowTemperature(ctx, id, maxFlowTemperature, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		return after, nil, err
+	}
+
+	return after, changes, nil
+}
+
 // GetWeather returns the weather of the home with the given ID.
-func (s *HomeService) GetWeather(ctx context.Context, id int) (*Weather, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/weather", id), nil)
+func (s *HomeService) GetWeather(ctx context.Context, id HomeID) (*Weather, error) {
+	req, err := s.client.newRequest("HomeService", "GetWeather", "GET", fmt.Sprintf("homes/%d/weather", id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -221,8 +283,8 @@ func (s *HomeService) GetWeather(ctx context.Context, id int) (*Weather, error)
 }
 
 // GetState returns the state of the home with the given ID.
-func (s *HomeService) GetState(ctx context.Context, id int) (*State, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/state", id), nil)
+func (s *HomeService) GetState(ctx context.Context, id HomeID) (*State, error) {
+	req, err := s.client.newRequest("HomeService", "GetState", "GET", fmt.Sprintf("homes/%d/state", id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -237,8 +299,34 @@ func (s *HomeService) GetState(ctx context.Context, id int) (*State, error) {
 }
 
 // SetState sets the state of the home with the given ID.
-func (s *HomeService) SetState(ctx context.Context, id int, presence Presence) error {
-	req, err := s.client.NewRequest("PUT", fmt.Sprintf("homes/%d/presenceLock", id), &map[string]string{"homePresence": string(presence)})
+//
+// Deprecated: SetState can only lock the presence. Use SetPresenceLock, and
+// DeletePresenceLock to return to geofencing.
+func (s *HomeService) SetState(ctx context.Context, id HomeID, presence Presence, opts ...WriteOption) error {
+	return s.SetPresenceLock(ctx, id, presence, opts...)
+}
+
+// SetPresenceLock locks the presence of the home with the given ID, so that
+// geofencing no longer changes it until the lock is removed using
+// DeletePresenceLock.
+//
+// Use WithIfCurrently to only change the presence if the home currently has a
+// given presence.
+func (s *HomeService) SetPresenceLock(ctx context.Context, id HomeID, presence Presence, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	if o.ifCurrently != nil {
+		state, err := s.GetState(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if state.Presence != *o.ifCurrently {
+			return fmt.Errorf("%w: home %d is %s, not %s", ErrPreconditionFailed, id, state.Presence, *o.ifCurrently)
+		}
+	}
+
+	req, err := s.client.newRequest("HomeService", "SetPresenceLock", "PUT", fmt.Sprintf("homes/%d/presenceLock", id), &map[string]string{"homePresence": string(presence)}, o.requestOptions...)
 	if err != nil {
 		return err
 	}
@@ -250,3 +338,122 @@ func (s *HomeService) SetState(ctx context.Context, id int, presence Presence) e
 
 	return nil
 }
+
+// UpdateDetails updates the name, contact details, address and geolocation
+// of the home with the given ID, and returns the updated home. Only the
+// non-nil fields of details are changed; the other details are taken from the
+// current home.
+func (s *HomeService) UpdateDetails(ctx context.Context, id HomeID, details HomeDetails, opts ...WriteOption) (*Home, error) {
+	o := newWriteOptions(opts)
+
+	// the current home is read bypassing the response cache, so that a stale
+	// cached home does not overwrite recent changes
+	home, err := s.get(ctx, id, bypassCache())
+	if err != nil {
+		return nil, err
+	}
+
+	if details.Name != nil {
+		home.Name = *details.Name
+	}
+	if details.ContactDetails != nil {
+		home.ContactDetails = *details.ContactDetails
+	}
+	if details.Address != nil {
+		home.Address = *details.Address
+	}
+	if details.Geolocation != nil {
+		home.Geolocation = *details.Geolocation
+	}
+
+	body := struct {
+		Name           string         `json:"name"`
+		ContactDetails ContactDetails `json:"contactDetails"`
+		Address        Address        `json:"address"`
+		Geolocation    Geolocation    `json:"geolocation"`
+	}{home.Name, home.ContactDetails, home.Address, home.Geolocation}
+
+	req, err := s.client.newRequest("HomeService", "UpdateDetails", "PUT", fmt.Sprintf("homes/%d/details", id), &body, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return home, nil
+}
+
+// DeletePresenceLock removes the presence lock of the home with the given ID,
+// so that its presence is determined by geofencing again.
+func (s *HomeService) DeletePresenceLock(ctx context.Context, id HomeID, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("HomeService", "DeletePresenceLock", "DELETE", fmt.Sprintf("homes/%d/presenceLock", id), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// HomeSummary is a lightweight summary of a home for inventory reporting.
+type HomeSummary struct {
+	ID                    HomeID         `json:"id"`
+	Name                  string         `json:"name"`
+	Generation            string         `json:"generation"`
+	ZonesCount            int            `json:"zonesCount"`
+	DevicesCount          int            `json:"devicesCount"`
+	DevicesByType         map[string]int `json:"devicesByType"`
+	OfflineDevicesCount   int            `json:"offlineDevicesCount"`
+	IsAirComfortEligible  bool           `json:"isAirComfortEligible"`
+	IsBalanceAcEligible   bool           `json:"isBalanceAcEligible"`
+	IsEnergyIqEligible    bool           `json:"isEnergyIqEligible"`
+	IsHeatSourceInstalled bool           `json:"isHeatSourceInstalled"`
+	IsHeatPumpInstalled   bool           `json:"isHeatPumpInstalled"`
+}
+
+// Summary returns a summary of the home with the given ID. It only requires
+// the home details and its device list, making it cheap to call for many
+// homes.
+func (s *HomeService) Summary(ctx context.Context, id HomeID) (*HomeSummary, error) {
+	home, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := (*DeviceService)(s).GetDeviceList(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &HomeSummary{
+		ID:                    home.ID,
+		Name:                  home.Name,
+		Generation:            home.Generation,
+		ZonesCount:            home.ZonesCount,
+		DevicesCount:          len(entries),
+		DevicesByType:         map[string]int{},
+		IsAirComfortEligible:  home.IsAirComfortEligible,
+		IsBalanceAcEligible:   home.IsBalanceAcEligible,
+		IsEnergyIqEligible:    home.IsEnergyIqEligible,
+		IsHeatSourceInstalled: home.IsHeatSourceInstalled,
+		IsHeatPumpInstalled:   home.IsHeatPumpInstalled,
+	}
+
+	for _, entry := range entries {
+		summary.DevicesByType[entry.Device.DeviceType]++
+		if !entry.Device.IsOnline() {
+			summary.OfflineDevicesCount++
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,141 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BoostTemperature and BoostDuration are the temperature, in degrees Celsius,
+// and the duration of the overlays set by HomeService.Boost.
+var (
+	BoostTemperature = 25.0
+	BoostDuration    = 30 * time.Minute
+)
+
+// ZoneOverlay is the overlay of a single zone in a bulk overlay request.
+type ZoneOverlay struct {
+	ZoneID  ZoneID
+	Overlay *Overlay
+}
+
+// SetOverlays sets the given overlays on the zones of the home with the given
+// ID in a single request.
+func (s *HomeService) SetOverlays(ctx context.Context, homeID HomeID, overlays []ZoneOverlay, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	type roomOverlay struct {
+		Room    ZoneID `json:"room"`
+		Overlay any    `json:"overlay"`
+	}
+	body := struct {
+		Overlays []roomOverlay `json:"overlays"`
+	}{}
+	for _, overlay := range overlays {
+		body.Overlays = append(body.Overlays, roomOverlay{Room: overlay.ZoneID, Overlay: overlay.Overlay.writeBody()})
+	}
+
+	req, err := s.client.newRequest("HomeService", "SetOverlays", "POST", fmt.Sprintf("homes/%d/overlay", homeID), &body, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteOverlays removes the overlays of the zones with the given IDs of the
+// provided home ID in a single request, so that they follow their schedule
+// again.
+func (s *HomeService) DeleteOverlays(ctx context.Context, homeID HomeID, zoneIDs []ZoneID, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	rooms := make([]string, len(zoneIDs))
+	for i, id := range zoneIDs {
+		rooms[i] = strconv.Itoa(int(id))
+	}
+
+	req, err := s.client.newRequest("HomeService", "DeleteOverlays", "DELETE", fmt.Sprintf("homes/%d/overlay?rooms=%s", homeID, strings.Join(rooms, ",")), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Boost heats all heating zones of the home with the given ID to
+// BoostTemperature for BoostDuration, after which they follow their schedule
+// again.
+func (s *HomeService) Boost(ctx context.Context, homeID HomeID, opts ...WriteOption) error {
+	zones, err := (*ZoneService)(s).List(ctx, homeID)
+	if err != nil {
+		return err
+	}
+
+	var overlays []ZoneOverlay
+	for _, zone := range zones {
+		if zone.Type != ZoneTypeHeating {
+			continue
+		}
+
+		overlay := NewOverlay(HeatingSetting(BoostTemperature), TimerTermination(BoostDuration))
+		overlays = append(overlays, ZoneOverlay{ZoneID: zone.ID, Overlay: overlay})
+	}
+
+	if len(overlays) == 0 {
+		return nil
+	}
+
+	return s.SetOverlays(ctx, homeID, overlays, opts...)
+}
+
+// TurnOffAllZones switches off all zones of the home with the given ID until
+// their overlays are removed, e.g. using ResumeSchedule.
+func (s *HomeService) TurnOffAllZones(ctx context.Context, homeID HomeID, opts ...WriteOption) error {
+	zones, err := (*ZoneService)(s).List(ctx, homeID)
+	if err != nil {
+		return err
+	}
+
+	if len(zones) == 0 {
+		return nil
+	}
+
+	overlays := make([]ZoneOverlay, len(zones))
+	for i, zone := range zones {
+		overlays[i] = ZoneOverlay{ZoneID: zone.ID, Overlay: NewOverlay(OffSetting(zone.Type), ManualTermination())}
+	}
+
+	return s.SetOverlays(ctx, homeID, overlays, opts...)
+}
+
+// ResumeSchedule removes the overlays of all zones of the home with the given
+// ID, so that they follow their schedule again.
+func (s *HomeService) ResumeSchedule(ctx context.Context, homeID HomeID, opts ...WriteOption) error {
+	zones, err := (*ZoneService)(s).List(ctx, homeID)
+	if err != nil {
+		return err
+	}
+
+	if len(zones) == 0 {
+		return nil
+	}
+
+	zoneIDs := make([]ZoneID, len(zones))
+	for i, zone := range zones {
+		zoneIDs[i] = zone.ID
+	}
+
+	return s.DeleteOverlays(ctx, homeID, zoneIDs, opts...)
+}
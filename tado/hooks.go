@@ -0,0 +1,54 @@
+package tado
+
+import (
+	"net/http"
+	"time"
+)
+
+// RequestHook is called with every request right before it is sent, e.g. to
+// log it or start a trace span. The Operation of the request can be obtained
+// from its context using OperationFromContext. It must not modify the
+// request.
+type RequestHook func(*http.Request)
+
+// ResponseHook is called after every request with its response, which is nil
+// if no response was received, the transport error, if any, and the time the
+// request took. Retried requests call the hooks for every attempt.
+type ResponseHook func(*Response, error, time.Duration)
+
+// WithRequestHook adds a hook that is called with every request sent by the
+// client, see RequestHook. Hooks are called in the order they were added.
+func WithRequestHook(hook RequestHook) ClientOption {
+	return func(c *Client) {
+		c.requestHooks = append(c.requestHooks, hook)
+	}
+}
+
+// WithResponseHook adds a hook that is called with the response to every
+// request sent by the client, see ResponseHook. Hooks are called in the order
+// they were added.
+func WithResponseHook(hook ResponseHook) ClientOption {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// beforeRequest calls the request hooks with req.
+func (c *Client) beforeRequest(req *http.Request) {
+	for _, hook := range c.requestHooks {
+		hook(req)
+	}
+}
+
+// afterResponse calls the response hooks with the response to a request sent
+// at start.
+func (c *Client) afterResponse(res *Response, err error, start time.Time) {
+	if len(c.responseHooks) == 0 {
+		return
+	}
+
+	d := time.Since(start)
+	for _, hook := range c.responseHooks {
+		hook(res, err, d)
+	}
+}
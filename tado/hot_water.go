@@ -0,0 +1,68 @@
+package tado
+
+import "context"
+
+// HotWaterSetting is the setting of a hot water zone. Temperature is nil when
+// the zone is switched off, and for boilers that can only be switched on and
+// off.
+type HotWaterSetting struct {
+	Power       Power
+	Temperature *Temperature
+}
+
+// ZoneSetting returns the ZoneSetting corresponding to the hot water setting.
+func (s HotWaterSetting) ZoneSetting() ZoneSetting {
+	setting := ZoneSetting{Type: ZoneTypeHotWater, Power: s.Power}
+	if s.Power == PowerOn && s.Temperature != nil {
+		t := *s.Temperature
+		setting.Temperature = &t
+	}
+
+	return setting
+}
+
+// HotWater returns the setting as a HotWaterSetting, and whether it is the
+// setting of a hot water zone.
+func (s ZoneSetting) HotWater() (HotWaterSetting, bool) {
+	if s.Type != ZoneTypeHotWater {
+		return HotWaterSetting{}, false
+	}
+
+	return HotWaterSetting{Power: s.Power, Temperature: s.Temperature}, true
+}
+
+// SetHotWater sets a hot water overlay with the given termination on the zone
+// with the given ID of the provided home ID, switching hot water on or off. If
+// temperature is not nil, the water is heated to that temperature, in the
+// preferred unit of the client (see Client.PreferredUnit); this requires a
+// zone that supports setting its temperature, see ZoneCapabilities.
+//
+// Example usage:
+//
+//	_, err := client.Zone.SetHotWater(ctx, homeID, zoneID, true, nil, tado.TimerTermination(time.Hour))
+func (s *ZoneService) SetHotWater(ctx context.Context, homeID HomeID, zoneID ZoneID, on bool, temperature *float64, termination Termination, opts ...WriteOption) (*Overlay, error) {
+	setting := HotWaterSetting{Power: PowerOff}
+	if on {
+		setting.Power = PowerOn
+	}
+
+	if on && temperature != nil {
+		unit, err := s.client.PreferredUnit(ctx, homeID)
+		if err != nil {
+			return nil, err
+		}
+
+		capabilities, err := s.GetCapabilities(ctx, homeID, zoneID)
+		if err != nil {
+			return nil, err
+		}
+
+		t := NewTemperature(*temperature, unit)
+		if err := capabilities.validateTemperature(t); err != nil {
+			return nil, err
+		}
+		setting.Temperature = &t
+	}
+
+	return s.SetOverlay(ctx, homeID, zoneID, NewOverlay(setting.ZoneSetting(), termination), opts...)
+}
@@ -0,0 +1,14 @@
+package tado
+
+// HomeID is the ID of a Tado home.
+type HomeID int
+
+// ZoneID is the ID of a zone, i.e. a room or a hot water circuit, within a
+// home.
+type ZoneID int
+
+// DeviceSerial is the serial number of a Tado device, e.g. "VA1234567890".
+type DeviceSerial string
+
+// RoomID is the ID of a room of a Tado X home, see RoomService.
+type RoomID int
@@ -0,0 +1,197 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DefaultMinderURL is the base URL of the Tado incident ("minder") API.
+const DefaultMinderURL = "https://minder.tado.com/v1/"
+
+// WithMinderURL sets the base URL of the Tado minder API, which serves
+// incidents and running times, e.g. to point the client at a mock server. A
+// trailing slash is added if missing. By default, DefaultMinderURL is used.
+func WithMinderURL(minderURL string) ClientOption {
+	return func(c *Client) {
+		c.minderURL = c.parseServiceURL("minder", minderURL)
+	}
+}
+
+// minderPath returns the URL of the given path of the minder API.
+func (c *Client) minderPath(format string, args ...any) string {
+	return c.minderURL.String() + fmt.Sprintf(format, args...)
+}
+
+// IncidentType represents the type of a Tado incident. The API may report
+// types not listed below, which are passed through unchanged.
+type IncidentType string
+
+const (
+	IncidentTypeFrost IncidentType = "FROST"
+)
+
+// IncidentEventType represents the type of an IncidentEvent.
+type IncidentEventType string
+
+const (
+	IncidentOpened   IncidentEventType = "OPENED"
+	IncidentResolved IncidentEventType = "RESOLVED"
+	IncidentFailed   IncidentEventType = "FAILED"
+)
+
+// IncidentDetection represents the incident detection settings of a Tado home.
+type IncidentDetection struct {
+	Supported bool `json:"supported"`
+	Enabled   bool `json:"enabled"`
+}
+
+// Incident represents an incident, such as a frost warning of type
+// IncidentTypeFrost, detected in a Tado home.
+type Incident struct {
+	ID        string       `json:"id"`
+	Type      IncidentType `json:"type"`
+	Status    string       `json:"status"`
+	ZoneID    ZoneID       `json:"zoneId,omitempty"`
+	CreatedAt time.Time    `json:"createdAt"`
+	UpdatedAt time.Time    `json:"updatedAt,omitempty"`
+}
+
+// IncidentEvent is delivered by WatchIncidents when an incident is opened or
+// resolved, or when polling the incidents fails.
+type IncidentEvent struct {
+	Type     IncidentEventType
+	HomeID   HomeID
+	Incident Incident
+	Err      error
+}
+
+// GetIncidentDetection returns the incident detection settings of the home
+// with the given ID.
+func (s *HomeService) GetIncidentDetection(ctx context.Context, id HomeID) (*IncidentDetection, error) {
+	req, err := s.client.newRequest("HomeService", "GetIncidentDetection", "GET", fmt.Sprintf("homes/%d/incidentDetection", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var incidentDetection *IncidentDetection
+	_, err = s.client.Do(ctx, req, &incidentDetection)
+	if err != nil {
+		return nil, err
+	}
+
+	return incidentDetection, nil
+}
+
+// SetIncidentDetection enables or disables incident detection for the home
+// with the given ID.
+func (s *HomeService) SetIncidentDetection(ctx context.Context, id HomeID, enabled bool, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("HomeService", "SetIncidentDetection", "PUT", fmt.Sprintf("homes/%d/incidentDetection", id), &map[string]bool{"enabled": enabled}, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListIncidents returns the open incidents of the home with the given ID.
+func (s *HomeService) ListIncidents(ctx context.Context, id HomeID) ([]Incident, error) {
+	req, err := s.client.newRequest("HomeService", "ListIncidents", "GET", s.client.minderPath("homes/%d/incidents", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents struct {
+		Incidents []Incident `json:"incidents"`
+	}
+	_, err = s.client.Do(ctx, req, &incidents)
+	if err != nil {
+		return nil, err
+	}
+
+	return incidents.Incidents, nil
+}
+
+// WatchIncidents polls the incidents of the home with the given ID at the
+// given interval and delivers an IncidentEvent for every incident that is
+// opened or resolved. Polling errors are delivered as IncidentFailed events.
+// A non-positive interval defaults to DefaultWatchInterval.
+//
+// The Tado API does not allow incidents to be acknowledged; incidents are
+// reported as resolved once they disappear from the API.
+//
+// The returned channel is closed when ctx is done.
+func (s *HomeService) WatchIncidents(ctx context.Context, id HomeID, interval time.Duration) <-chan IncidentEvent {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	events := make(chan IncidentEvent)
+
+	untrack := s.client.trackSubscription(fmt.Sprintf("incidents/home/%d", id))
+	ctx = ContextWithPriority(ctx, PriorityLow)
+
+	go func() {
+		defer untrack()
+		defer close(events)
+
+		known := map[string]Incident{}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			incidents, err := s.ListIncidents(ctx, id)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if !sendIncidentEvent(ctx, events, IncidentEvent{Type: IncidentFailed, HomeID: id, Err: err}) {
+					return
+				}
+			} else {
+				current := make(map[string]Incident, len(incidents))
+				for _, incident := range incidents {
+					current[incident.ID] = incident
+					if _, ok := known[incident.ID]; !ok {
+						if !sendIncidentEvent(ctx, events, IncidentEvent{Type: IncidentOpened, HomeID: id, Incident: incident}) {
+							return
+						}
+					}
+				}
+				for incidentID, incident := range known {
+					if _, ok := current[incidentID]; !ok {
+						if !sendIncidentEvent(ctx, events, IncidentEvent{Type: IncidentResolved, HomeID: id, Incident: incident}) {
+							return
+						}
+					}
+				}
+				known = current
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events
+}
+
+// sendIncidentEvent sends e on events, returning false if ctx is done first.
+func sendIncidentEvent(ctx context.Context, events chan<- IncidentEvent, e IncidentEvent) bool {
+	select {
+	case events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,61 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddDevice adds (pairs) the device with the given serial number and
+// authentication code, as printed on the device, to the home with the given
+// ID.
+func (s *HomeService) AddDevice(ctx context.Context, id HomeID, serialNo DeviceSerial, authCode string, opts ...WriteOption) (*Device, error) {
+	o := newWriteOptions(opts)
+
+	body := &map[string]string{"serialNo": string(serialNo), "authCode": authCode}
+	req, err := s.client.newRequest("HomeService", "AddDevice", "POST", fmt.Sprintf("homes/%d/devices", id), body, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var device *Device
+	_, err = s.client.Do(ctx, req, &device)
+	if err != nil {
+		return nil, err
+	}
+
+	return device, nil
+}
+
+// SetMeasuringDevice makes the device with the given serial number the
+// measuring device of the given zone, i.e. the device whose temperature
+// measurements are used to control the zone.
+func (s *HomeService) SetMeasuringDevice(ctx context.Context, homeID HomeID, zoneID ZoneID, serialNo DeviceSerial, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("HomeService", "SetMeasuringDevice", "PUT", fmt.Sprintf("homes/%d/zones/%d/measuringDevice", homeID, zoneID), &map[string]string{"serialNo": string(serialNo)}, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PairTemperatureSensor pairs a wireless temperature sensor with the home with
+// the given ID and assigns it as the measuring device of the given zone.
+func (s *HomeService) PairTemperatureSensor(ctx context.Context, homeID HomeID, zoneID ZoneID, serialNo DeviceSerial, authCode string, opts ...WriteOption) (*Device, error) {
+	device, err := s.AddDevice(ctx, homeID, serialNo, authCode, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("pairing sensor %s: %w", serialNo, err)
+	}
+
+	if err := s.SetMeasuringDevice(ctx, homeID, zoneID, serialNo, opts...); err != nil {
+		return device, fmt.Errorf("assigning sensor %s to zone %d: %w", serialNo, zoneID, err)
+	}
+
+	return device, nil
+}
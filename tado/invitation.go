@@ -0,0 +1,120 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Installation represents an installation of a Tado home, i.e. a set of
+// devices installed together.
+type Installation struct {
+	ID       int      `json:"id"`
+	Type     string   `json:"type"`
+	Revision int      `json:"revision"`
+	State    string   `json:"state"`
+	Devices  []Device `json:"devices"`
+}
+
+// Invitation represents an invitation of a user to a Tado home.
+type Invitation struct {
+	Token     string    `json:"token" redact:"true"`
+	Email     string    `json:"email" redact:"true"`
+	FirstSent time.Time `json:"firstSent"`
+	LastSent  time.Time `json:"lastSent"`
+	Inviter   struct {
+		Name     string `json:"name" redact:"true"`
+		Email    string `json:"email" redact:"true"`
+		Username string `json:"username" redact:"true"`
+	} `json:"inviter"`
+	Home BareHome `json:"home"`
+}
+
+// ListInstallations returns the installations of the home with the given ID.
+func (s *HomeService) ListInstallations(ctx context.Context, id HomeID) ([]Installation, error) {
+	req, err := s.client.newRequest("HomeService", "ListInstallations", "GET", fmt.Sprintf("homes/%d/installations", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var installations []Installation
+	_, err = s.client.Do(ctx, req, &installations)
+	if err != nil {
+		return nil, err
+	}
+
+	return installations, nil
+}
+
+// ListInvitations returns the pending invitations of the home with the given
+// ID.
+func (s *HomeService) ListInvitations(ctx context.Context, id HomeID) ([]Invitation, error) {
+	req, err := s.client.newRequest("HomeService", "ListInvitations", "GET", fmt.Sprintf("homes/%d/invitations", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var invitations []Invitation
+	_, err = s.client.Do(ctx, req, &invitations)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+// CreateInvitation invites the user with the given email address to the home
+// with the given ID.
+func (s *HomeService) CreateInvitation(ctx context.Context, id HomeID, email string, opts ...WriteOption) (*Invitation, error) {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("HomeService", "CreateInvitation", "POST", fmt.Sprintf("homes/%d/invitations", id), &map[string]string{"email": email}, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var invitation *Invitation
+	_, err = s.client.Do(ctx, req, &invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// ResendInvitation sends the invitation with the given token of the home with
+// the given ID again.
+func (s *HomeService) ResendInvitation(ctx context.Context, id HomeID, token string, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("HomeService", "ResendInvitation", "POST", fmt.Sprintf("homes/%d/invitations/%s/resend", id, url.PathEscape(token)), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteInvitation revokes the invitation with the given token of the home
+// with the given ID.
+func (s *HomeService) DeleteInvitation(ctx context.Context, id HomeID, token string, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("HomeService", "DeleteInvitation", "DELETE", fmt.Sprintf("homes/%d/invitations/%s", id, url.PathEscape(token)), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package tado
+
+import "iter"
+
+// seq returns an iter.Seq2 that lazily calls list and yields its items one by
+// one. If list fails, the error is yielded once with the zero value of T.
+func seq[T any](list func() ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		items, err := list()
+		if err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
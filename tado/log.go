@@ -0,0 +1,124 @@
+package tado
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WithLogger makes the client log every request at debug level to logger,
+// with its method, path, operation, status, latency and the rate-limit
+// headers of the response, as well as every retry. Bodies are redacted,
+// unless WithVerboseLogging is set.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithVerboseLogging makes the client log the bodies of requests and
+// responses, see WithLogger. The bodies may contain personal data and tokens,
+// so this should only be enabled while diagnosing a problem.
+func WithVerboseLogging() ClientOption {
+	return func(c *Client) {
+		c.logBodies = true
+	}
+}
+
+// logResponse logs the request req and its response, which is nil if no
+// response was received, at debug level.
+func (c *Client) logResponse(ctx context.Context, req *http.Request, res *Response, err error, d time.Duration) {
+	if c.logger == nil || !c.logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Duration("latency", d),
+	}
+	if op, ok := OperationFromContext(ctx); ok && op.Name != "" {
+		attrs = append(attrs, slog.String("operation", op.Service+"."+op.Name))
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		attrs = append(attrs, c.bodyAttr("request_body", req.GetBody))
+	}
+
+	if res != nil {
+		attrs = append(attrs, slog.Int("status", res.StatusCode))
+		if headers := rateLimitHeaders(res.Header); len(headers) > 0 {
+			attrs = append(attrs, slog.Group("rate_limit", headers...))
+		}
+		attrs = append(attrs, c.bodyAttr("response_body", func() (io.ReadCloser, error) {
+			data, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			res.Body = io.NopCloser(bytes.NewReader(data))
+			return io.NopCloser(bytes.NewReader(data)), err
+		}))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("error", err))
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "tado request", attrs...)
+}
+
+// logRetry logs that a request failed with err is retried after wait.
+func (c *Client) logRetry(ctx context.Context, req *http.Request, attempt int, wait time.Duration, err error) {
+	if c.logger == nil {
+		return
+	}
+
+	c.logger.LogAttrs(ctx, slog.LevelDebug, "tado retry",
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("wait", wait),
+		slog.Any("error", err),
+	)
+}
+
+// bodyAttr returns an attribute holding the body returned by get, or
+// "[REDACTED]" if the client does not log bodies.
+func (c *Client) bodyAttr(key string, get func() (io.ReadCloser, error)) slog.Attr {
+	if !c.logBodies {
+		return slog.String(key, redacted)
+	}
+	if get == nil {
+		return slog.String(key, "")
+	}
+
+	body, err := get()
+	if err != nil {
+		return slog.String(key, "")
+	}
+	defer body.Close()
+
+	data, _ := io.ReadAll(body)
+	return slog.String(key, string(data))
+}
+
+// rateLimitHeaders returns the rate-limit headers of a response as
+// attributes, e.g. RateLimit, RateLimit-Policy and Retry-After.
+func rateLimitHeaders(header http.Header) []any {
+	var names []string
+	for name := range header {
+		lower := strings.ToLower(name)
+		if strings.Contains(lower, "ratelimit") || lower == "retry-after" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	attrs := make([]any, 0, len(names))
+	for _, name := range names {
+		attrs = append(attrs, slog.String(strings.ToLower(name), header.Get(name)))
+	}
+
+	return attrs
+}
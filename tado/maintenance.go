@@ -0,0 +1,130 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Maintenance is a maintenance window started by HomeService.MaintenanceMode.
+// It holds the state of the home before the window, so that it can be
+// restored.
+type Maintenance struct {
+	HomeID HomeID
+	Until  time.Time
+
+	service  *HomeService
+	state    State
+	overlays map[ZoneID]*Overlay
+}
+
+// MaintenanceMode starts a maintenance window of duration d for the home with
+// the given ID, e.g. for boiler servicing or window replacement days. It
+// switches off all zones and locks the presence of the home to HOME, so that
+// geofencing does not switch them back on.
+//
+// The zones are switched off using timer overlays lasting d, so that they
+// return to their schedule even if the window is never restored. The presence
+// lock and any overlays the zones had before are restored by
+// Maintenance.Restore, or by Maintenance.Wait at the end of the window.
+//
+// If some zones cannot be switched off, the other zones stay switched off and
+// the Maintenance is returned together with a *MultiError.
+func (s *HomeService) MaintenanceMode(ctx context.Context, homeID HomeID, d time.Duration) (*Maintenance, error) {
+	state, err := s.GetState(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := (*ZoneService)(s).List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Maintenance{
+		HomeID:   homeID,
+		Until:    time.Now().Add(d),
+		service:  s,
+		state:    *state,
+		overlays: map[ZoneID]*Overlay{},
+	}
+
+	if err := s.SetPresenceLock(ctx, homeID, PresenceHome); err != nil {
+		return nil, err
+	}
+
+	errs := &MultiError{}
+	for _, zone := range zones {
+		overlay, err := (*ZoneService)(s).GetOverlay(ctx, homeID, zone.ID)
+		if err != nil {
+			errs.Add(fmt.Sprintf("zone %d", zone.ID), err)
+			continue
+		}
+
+		off := NewOverlay(OffSetting(zone.Type), TimerTermination(d))
+		if _, err := (*ZoneService)(s).SetOverlay(ctx, homeID, zone.ID, off); err != nil {
+			errs.Add(fmt.Sprintf("zone %d", zone.ID), err)
+			continue
+		}
+
+		m.overlays[zone.ID] = overlay
+	}
+
+	return m, errs.ErrorOrNil()
+}
+
+// Wait blocks until the end of the maintenance window and then restores the
+// home. If ctx is done first, the home is restored early and the error of ctx
+// is returned together with any error restoring it.
+func (m *Maintenance) Wait(ctx context.Context) error {
+	timer := time.NewTimer(time.Until(m.Until))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return m.Restore(ctx)
+	case <-ctx.Done():
+		if err := m.Restore(context.WithoutCancel(ctx)); err != nil {
+			return fmt.Errorf("%w (restoring: %v)", ctx.Err(), err)
+		}
+		return ctx.Err()
+	}
+}
+
+// Restore ends the maintenance window, restoring the presence lock and the
+// overlays of the zones switched off by MaintenanceMode. Zones that had no
+// overlay return to their schedule.
+//
+// If some zones cannot be restored, the other zones are still restored and a
+// *MultiError is returned.
+func (m *Maintenance) Restore(ctx context.Context) error {
+	errs := &MultiError{}
+
+	for zoneID, overlay := range m.overlays {
+		var err error
+		if overlay == nil {
+			err = (*ZoneService)(m.service).DeleteOverlay(ctx, m.HomeID, zoneID)
+		} else {
+			_, err = (*ZoneService)(m.service).SetOverlay(ctx, m.HomeID, zoneID, overlay)
+		}
+
+		if err != nil {
+			errs.Add(fmt.Sprintf("zone %d", zoneID), err)
+			continue
+		}
+
+		delete(m.overlays, zoneID)
+	}
+
+	var err error
+	if m.state.PresenceLocked {
+		err = m.service.SetPresenceLock(ctx, m.HomeID, m.state.Presence)
+	} else {
+		err = m.service.DeletePresenceLock(ctx, m.HomeID)
+	}
+	if err != nil {
+		errs.Add("presence", err)
+	}
+
+	return errs.ErrorOrNil()
+}
@@ -3,6 +3,7 @@ package tado
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // MobileDeviceService handles communication with the mobile device-related
@@ -11,7 +12,7 @@ type MobileDeviceService service
 
 // MobileDevice represents a Tado mobile device.
 type MobileDevice struct {
-	Name     string               `json:"name,omitempty"`
+	Name     string               `json:"name,omitempty" redact:"true"`
 	ID       int                  `json:"id,omitempty"`
 	Settings MobileDeviceSettings `json:"settings,omitempty"`
 	Location struct {
@@ -48,9 +49,40 @@ type MobileDeviceSettings struct {
 	} `json:"pushNotifications"`
 }
 
+// writeBody implements the writable interface. It strips the read-only
+// onDemandLogRetrievalEnabled field and always sends the writable flags, so
+// that they can be disabled.
+func (s MobileDeviceSettings) writeBody() any {
+	// the push notification flags are converted to a struct without
+	// omitempty, so that false is sent as well
+	type pushNotifications struct {
+		LowBatteryReminder          bool `json:"lowBatteryReminder"`
+		AwayModeReminder            bool `json:"awayModeReminder"`
+		HomeModeReminder            bool `json:"homeModeReminder"`
+		OpenWindowReminder          bool `json:"openWindowReminder"`
+		EnergySavingsReportReminder bool `json:"energySavingsReportReminder"`
+		IncidentDetection           bool `json:"incidentDetection"`
+		EnergyIqReminder            bool `json:"energyIqReminder"`
+		TariffHighPriceAlert        bool `json:"tariffHighPriceAlert"`
+		TariffLowPriceAlert         bool `json:"tariffLowPriceAlert"`
+	}
+
+	body := struct {
+		GeoTrackingEnabled   bool              `json:"geoTrackingEnabled"`
+		SpecialOffersEnabled bool              `json:"specialOffersEnabled"`
+		PushNotifications    pushNotifications `json:"pushNotifications"`
+	}{
+		GeoTrackingEnabled:   s.GeoTrackingEnabled,
+		SpecialOffersEnabled: s.SpecialOffersEnabled,
+		PushNotifications:    pushNotifications(s.PushNotifications),
+	}
+
+	return &body
+}
+
 // List returns a list of all mobile devices for the provided home ID.
-func (s *MobileDeviceService) List(ctx context.Context, id int) (*[]MobileDevice, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/mobileDevices", id), nil)
+func (s *MobileDeviceService) List(ctx context.Context, id HomeID) (*[]MobileDevice, error) {
+	req, err := s.client.newRequest("MobileDeviceService", "List", "GET", fmt.Sprintf("homes/%d/mobileDevices", id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -64,9 +96,22 @@ func (s *MobileDeviceService) List(ctx context.Context, id int) (*[]MobileDevice
 	return mobileDevices, nil
 }
 
+// All returns an iterator over all mobile devices for the provided home ID. The
+// devices are fetched when the iteration starts.
+func (s *MobileDeviceService) All(ctx context.Context, id HomeID) iter.Seq2[MobileDevice, error] {
+	return seq(func() ([]MobileDevice, error) {
+		mobileDevices, err := s.List(ctx, id)
+		if err != nil || mobileDevices == nil {
+			return nil, err
+		}
+
+		return *mobileDevices, nil
+	})
+}
+
 // Get returns the mobile device with the given ID for the provided home ID.
-func (s *MobileDeviceService) Get(ctx context.Context, homeID, deviceID int) (*MobileDevice, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/mobileDevices/%d", homeID, deviceID), nil)
+func (s *MobileDeviceService) Get(ctx context.Context, homeID HomeID, deviceID int) (*MobileDevice, error) {
+	req, err := s.client.newRequest("MobileDeviceService", "Get", "GET", fmt.Sprintf("homes/%d/mobileDevices/%d", homeID, deviceID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -81,8 +126,10 @@ func (s *MobileDeviceService) Get(ctx context.Context, homeID, deviceID int) (*M
 }
 
 // Delete deletes the relationship between the given mobile device and home.
-func (s *MobileDeviceService) Delete(ctx context.Context, homeID, deviceID int) error {
-	req, err := s.client.NewRequest("DELETE", fmt.Sprintf("homes/%d/mobileDevices/%d", homeID, deviceID), nil)
+func (s *MobileDeviceService) Delete(ctx context.Context, homeID HomeID, deviceID int, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("MobileDeviceService", "Delete", "DELETE", fmt.Sprintf("homes/%d/mobileDevices/%d", homeID, deviceID), nil, o.requestOptions...)
 	if err != nil {
 		return err
 	}
@@ -96,8 +143,8 @@ func (s *MobileDeviceService) Delete(ctx context.Context, homeID, deviceID int)
 }
 
 // GetSettings returns the settings of the mobile device with the given ID for the provided home ID.
-func (s *MobileDeviceService) GetSettings(ctx context.Context, homeID, deviceID int) (*MobileDeviceSettings, error) {
-	req, err := s.client.NewRequest("GET", fmt.Sprintf("homes/%d/mobileDevices/%d/settings", homeID, deviceID), nil)
+func (s *MobileDeviceService) GetSettings(ctx context.Context, homeID HomeID, deviceID int) (*MobileDeviceSettings, error) {
+	req, err := s.client.newRequest("MobileDeviceService", "GetSettings", "GET", fmt.Sprintf("homes/%d/mobileDevices/%d/settings", homeID, deviceID), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +159,10 @@ func (s *MobileDeviceService) GetSettings(ctx context.Context, homeID, deviceID
 }
 
 // UpdateSettings updates the settings of the mobile device with the given ID for the provided home ID.
-func (s *MobileDeviceService) UpdateSettings(ctx context.Context, homeID, deviceID int, settings MobileDeviceSettings) (*MobileDeviceSettings, error) {
-	req, err := s.client.NewRequest("PUT", fmt.Sprintf("homes/%d/mobileDevices/%d/settings", homeID, deviceID), settings)
+func (s *MobileDeviceService) UpdateSettings(ctx context.Context, homeID HomeID, deviceID int, settings MobileDeviceSettings, opts ...WriteOption) (*MobileDeviceSettings, error) {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("MobileDeviceService", "UpdateSettings", "PUT", fmt.Sprintf("homes/%d/mobileDevices/%d/settings", homeID, deviceID), settings, o.requestOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -126,3 +175,102 @@ func (s *MobileDeviceService) UpdateSettings(ctx context.Context, homeID, device
 
 	return settings2, nil
 }
+
+// UpdateSettingsWithChanges updates the settings of the mobile device with the
+// given ID for the provided home ID, like UpdateSettings, and additionally
+// returns the fields that were changed by the update.
+func (s *MobileDeviceService) UpdateSettingsWithChanges(ctx context.Context, homeID HomeID, deviceID int, settings MobileDeviceSettings, opts ...WriteOption) (*MobileDeviceSettings, []FieldChange, error) {
+	before, err := s.GetSettings(ctx, homeID, deviceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	after, err := s.UpdateSettings(ctx, homeID, deviceID, settings, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		return after, nil, err
+	}
+
+	return after, changes, nil
+}
+
+// Warning describes a consequence of an operation that the caller should be
+// aware of before performing it.
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// RemovalWarnings returns warnings about home behaviors that depend on the
+// given mobile device, to be checked before disabling its geotracking or
+// deleting it from the home.
+func (s *MobileDeviceService) RemovalWarnings(ctx context.Context, homeID HomeID, deviceID int) ([]Warning, error) {
+	mobileDevices, err := s.List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var device *MobileDevice
+	geoTracking := 0
+	if mobileDevices != nil {
+		for i, d := range *mobileDevices {
+			if d.ID == deviceID {
+				device = &(*mobileDevices)[i]
+			}
+			if d.Settings.GeoTrackingEnabled {
+				geoTracking++
+			}
+		}
+	}
+
+	if device == nil {
+		return nil, fmt.Errorf("mobile device %d not found in home %d", deviceID, homeID)
+	}
+
+	home, err := (*HomeService)(s).Get(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []Warning
+
+	if device.Settings.GeoTrackingEnabled {
+		if geoTracking == 1 {
+			warnings = append(warnings, Warning{
+				Code:    "LAST_GEOTRACKING_DEVICE",
+				Message: "this is the only geotracking device of the home; away and home mode will no longer switch automatically",
+			})
+		}
+
+		for _, feature := range home.EnabledFeatures {
+			if feature == "AUTO_ASSIST" || feature == "AUTO_ASSIST_GEOFENCING" {
+				warnings = append(warnings, Warning{
+					Code:    "AUTO_ASSIST",
+					Message: "auto-assist uses the location of this device for geofencing",
+				})
+				break
+			}
+		}
+
+		if device.Location.AtHome && geoTracking > 1 {
+			warnings = append(warnings, Warning{
+				Code:    "AT_HOME",
+				Message: "this device is currently at home; removing it may switch the home to away mode",
+			})
+		}
+	}
+
+	notifications := device.Settings.PushNotifications
+	if notifications.AwayModeReminder || notifications.HomeModeReminder || notifications.OpenWindowReminder || notifications.LowBatteryReminder {
+		warnings = append(warnings, Warning{
+			Code:    "PUSH_NOTIFICATIONS",
+			Message: "this device receives reminders (away/home mode, open window, low battery) that will no longer be delivered",
+		})
+	}
+
+	return warnings, nil
+}
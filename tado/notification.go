@@ -0,0 +1,47 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// NotificationType represents the type of an in-app Tado notification.
+type NotificationType string
+
+const (
+	NotificationAwaySuggestion NotificationType = "AWAY_SUGGESTION"
+	NotificationHomeSuggestion NotificationType = "HOME_SUGGESTION"
+	NotificationOpenWindow     NotificationType = "OPEN_WINDOW"
+	NotificationReportReady    NotificationType = "REPORT_READY"
+)
+
+// Notification represents an entry of the in-app notification feed of a Tado
+// home.
+type Notification struct {
+	ID        string           `json:"id"`
+	Type      NotificationType `json:"type"`
+	Title     string           `json:"title,omitempty"`
+	Message   string           `json:"message,omitempty"`
+	ZoneID    ZoneID           `json:"zoneId,omitempty"`
+	Read      bool             `json:"read"`
+	CreatedAt time.Time        `json:"createdAt"`
+	Data      map[string]any   `json:"data,omitempty"`
+}
+
+// ListNotifications returns the in-app notification feed of the home with the
+// given ID.
+func (s *HomeService) ListNotifications(ctx context.Context, id HomeID) ([]Notification, error) {
+	req, err := s.client.newRequest("HomeService", "ListNotifications", "GET", fmt.Sprintf("homes/%d/notifications", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var notifications []Notification
+	_, err = s.client.Do(ctx, req, &notifications)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
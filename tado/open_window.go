@@ -0,0 +1,75 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+)
+
+// GetOpenWindowDetection returns the open window detection settings of the
+// zone with the given ID of the provided home ID.
+func (s *ZoneService) GetOpenWindowDetection(ctx context.Context, homeID HomeID, zoneID ZoneID) (*OpenWindowDetection, error) {
+	zone, err := s.Get(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zone.OpenWindowDetection, nil
+}
+
+// SetOpenWindowDetection enables or disables open window detection for the
+// zone with the given ID of the provided home ID. When a window is detected
+// open, heating is switched off for timeoutSeconds.
+func (s *ZoneService) SetOpenWindowDetection(ctx context.Context, homeID HomeID, zoneID ZoneID, enabled bool, timeoutSeconds int, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	body := &OpenWindowDetection{Enabled: enabled, TimeoutInSeconds: timeoutSeconds}
+	req, err := s.client.newRequest("ZoneService", "SetOpenWindowDetection", "PUT", fmt.Sprintf("homes/%d/zones/%d/openWindowDetection", homeID, zoneID), body, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ActivateOpenWindow activates open window mode for the zone with the given ID
+// of the provided home ID, e.g. when an external window sensor reports an open
+// window. The zone must have a detected open window, see
+// ZoneState.OpenWindowDetected.
+func (s *ZoneService) ActivateOpenWindow(ctx context.Context, homeID HomeID, zoneID ZoneID, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "ActivateOpenWindow", "POST", fmt.Sprintf("homes/%d/zones/%d/state/openWindow/activate", homeID, zoneID), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteOpenWindow ends open window mode for the zone with the given ID of the
+// provided home ID, so that the zone resumes heating.
+func (s *ZoneService) DeleteOpenWindow(ctx context.Context, homeID HomeID, zoneID ZoneID, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "DeleteOpenWindow", "DELETE", fmt.Sprintf("homes/%d/zones/%d/state/openWindow", homeID, zoneID), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
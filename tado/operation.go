@@ -0,0 +1,94 @@
+package tado
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// Operation describes the API operation a request is sent for. It is attached
+// to the context of every request created with NewRequest, so that
+// middleware, such as a RoundTripper set with WithHTTPClient, can label logs
+// and metrics without parsing URLs. Use OperationFromContext to retrieve it.
+type Operation struct {
+	// Service and Name are the service and method the request is sent by,
+	// e.g. "ZoneService" and "SetOverlay". They are empty for requests
+	// created outside of a service.
+	Service string
+	Name    string
+
+	// HomeID, ZoneID, RoomID and DeviceSerial identify the home, zone, room
+	// and device the request is about, or are zero if it is not about one.
+	HomeID       HomeID
+	ZoneID       ZoneID
+	RoomID       RoomID
+	DeviceSerial DeviceSerial
+}
+
+// operationKey is the context key of the Operation of a request.
+type operationKey struct{}
+
+// OperationFromContext returns the Operation of the request sent with ctx, and
+// whether it has one.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	op, ok := ctx.Value(operationKey{}).(Operation)
+	return op, ok
+}
+
+// withOperation returns a copy of ctx carrying the Operation of req, if any.
+func withOperation(ctx context.Context, req *http.Request) context.Context {
+	if op, ok := OperationFromContext(req.Context()); ok {
+		return context.WithValue(ctx, operationKey{}, op)
+	}
+
+	return ctx
+}
+
+// zonePathPattern, roomPathPattern and devicePathPattern match the zone ID,
+// room ID and device serial number in the path of a request.
+var (
+	zonePathPattern   = regexp.MustCompile(`/zones/(\d+)(/|$)`)
+	roomPathPattern   = regexp.MustCompile(`/rooms/(\d+)(/|$)`)
+	devicePathPattern = regexp.MustCompile(`/devices/([^/]+)`)
+)
+
+// newOperation returns the Operation of a request to the given URL, without
+// the service and method, which are set by newRequest.
+func newOperation(u *url.URL) Operation {
+	var op Operation
+	if m := homePathPattern.FindStringSubmatch(u.Path); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		op.HomeID = HomeID(id)
+	}
+	if m := zonePathPattern.FindStringSubmatch(u.Path); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		op.ZoneID = ZoneID(id)
+	}
+	if m := roomPathPattern.FindStringSubmatch(u.Path); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		op.RoomID = RoomID(id)
+	}
+	if m := devicePathPattern.FindStringSubmatch(u.Path); m != nil {
+		serialNo, _ := url.PathUnescape(m[1])
+		op.DeviceSerial = DeviceSerial(serialNo)
+	}
+
+	return op
+}
+
+// newRequest is like NewRequest, but labels the request with the given
+// service and method, e.g. "ZoneService" and "SetOverlay".
+func (c *Client) newRequest(service, name, method, path string, body any, opts ...RequestOption) (*http.Request, error) {
+	req, err := c.NewRequest(method, path, body, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	op, _ := OperationFromContext(req.Context())
+	op.Service, op.Name = service, name
+	*req = *req.WithContext(context.WithValue(req.Context(), operationKey{}, op))
+
+	return req, nil
+}
@@ -0,0 +1,60 @@
+package tado
+
+import "errors"
+
+// ErrPreconditionFailed is returned by write methods when a condition set
+// using a WriteOption such as WithIfCurrently is not met. Nothing is written
+// in that case.
+var ErrPreconditionFailed = errors.New("precondition failed")
+
+// ErrNotApplied is returned by write methods called with WithVerify when Tado
+// accepted the write but the change did not take effect.
+var ErrNotApplied = errors.New("change not applied")
+
+// WriteOption configures a write method. Options that do not apply to a
+// method are ignored by it.
+type WriteOption func(*writeOptions)
+
+// writeOptions holds the options of a write method.
+type writeOptions struct {
+	ifCurrently    *Presence
+	verify         bool
+	requestOptions []RequestOption
+}
+
+// newWriteOptions applies the given options.
+func newWriteOptions(opts []WriteOption) *writeOptions {
+	o := &writeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithIfCurrently makes HomeService.SetPresenceLock only change the presence
+// if the home currently has the given presence. ErrPreconditionFailed is
+// returned otherwise.
+func WithIfCurrently(presence Presence) WriteOption {
+	return func(o *writeOptions) {
+		o.ifCurrently = &presence
+	}
+}
+
+// WithRequestOptions applies the given RequestOptions to the requests sent by
+// a write method.
+func WithRequestOptions(opts ...RequestOption) WriteOption {
+	return func(o *writeOptions) {
+		o.requestOptions = append(o.requestOptions, opts...)
+	}
+}
+
+// WithVerify makes ZoneService.SetOverlay poll the zone state after the write,
+// using PollUntil, to confirm that the overlay took effect. If it did not
+// within a few seconds, the overlay is written once more; if it still does not
+// take effect, ErrNotApplied is returned.
+func WithVerify() WriteOption {
+	return func(o *writeOptions) {
+		o.verify = true
+	}
+}
@@ -0,0 +1,209 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	TerminationNextTimeBlock TerminationType = "NEXT_TIME_BLOCK"
+)
+
+// ManualTermination returns a Termination that keeps an overlay active until
+// it is removed.
+func ManualTermination() Termination {
+	return Termination{Type: TerminationManual}
+}
+
+// TimerTermination returns a Termination that ends an overlay after the given
+// duration, rounded to whole seconds. Positive durations below a second are
+// rounded up to one second.
+func TimerTermination(d time.Duration) Termination {
+	seconds := int(d.Round(time.Second) / time.Second)
+	if d > 0 {
+		seconds = max(seconds, 1)
+	}
+
+	return Termination{Type: TerminationTimer, DurationInSeconds: seconds}
+}
+
+// TadoModeTermination returns a Termination that ends an overlay when the
+// presence (tado mode) of the home changes.
+func TadoModeTermination() Termination {
+	return Termination{Type: TerminationTadoMode}
+}
+
+// NextTimeBlockTermination returns a Termination that ends an overlay at the
+// start of the next block of the schedule.
+func NextTimeBlockTermination() Termination {
+	return Termination{Type: TerminationNextTimeBlock}
+}
+
+// HeatingSetting returns a ZoneSetting that heats a zone to the given
+// temperature in degrees Celsius.
+func HeatingSetting(celsius float64) ZoneSetting {
+	t := Celsius(celsius)
+	return ZoneSetting{
+		Type:        ZoneTypeHeating,
+		Power:       PowerOn,
+		Temperature: &t,
+	}
+}
+
+// OffSetting returns a ZoneSetting that switches off a zone of the given type.
+func OffSetting(zoneType ZoneType) ZoneSetting {
+	return ZoneSetting{Type: zoneType, Power: PowerOff}
+}
+
+// NewOverlay returns an Overlay with the given setting and termination.
+func NewOverlay(setting ZoneSetting, termination Termination) *Overlay {
+	return &Overlay{
+		Type:        "MANUAL",
+		Setting:     setting,
+		Termination: termination,
+	}
+}
+
+// writeBody implements the writable interface. It strips the read-only
+// expiry fields of the termination.
+func (o Overlay) writeBody() any {
+	body := struct {
+		Type        string      `json:"type,omitempty"`
+		Setting     ZoneSetting `json:"setting"`
+		Termination struct {
+			Type              TerminationType `json:"type"`
+			DurationInSeconds int             `json:"durationInSeconds,omitempty"`
+		} `json:"termination"`
+	}{
+		Type:    o.Type,
+		Setting: o.Setting,
+	}
+	body.Termination.Type = o.Termination.Type
+	body.Termination.DurationInSeconds = o.Termination.DurationInSeconds
+
+	return &body
+}
+
+// GetOverlay returns the overlay of the zone with the given ID of the provided
+// home ID, or nil if the zone follows its schedule.
+func (s *ZoneService) GetOverlay(ctx context.Context, homeID HomeID, zoneID ZoneID) (*Overlay, error) {
+	state, err := s.GetState(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Overlay, nil
+}
+
+// SetOverlay sets the overlay of the zone with the given ID of the provided
+// home ID and returns the overlay as applied by Tado.
+//
+// Example usage:
+//
+//	overlay := tado.NewOverlay(tado.HeatingSetting(21), tado.TimerTermination(30*time.Minute))
+//	_, err := client.Zone.SetOverlay(ctx, homeID, zoneID, overlay)
+func (s *ZoneService) SetOverlay(ctx context.Context, homeID HomeID, zoneID ZoneID, overlay *Overlay, opts ...WriteOption) (*Overlay, error) {
+	o := newWriteOptions(opts)
+
+	applied, err := s.setOverlay(ctx, homeID, zoneID, overlay, o)
+	if err != nil || !o.verify {
+		return applied, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		_, err := PollUntil(ctx, s.client,
+			func(ctx context.Context) (*Overlay, error) {
+				return s.GetOverlay(ctx, homeID, zoneID)
+			},
+			func(current *Overlay) bool {
+				return current != nil && current.Setting.matches(overlay.Setting)
+			},
+			verifyInterval, verifyTimeout)
+		if err == nil {
+			return applied, nil
+		}
+		if !errors.Is(err, ErrPollTimeout) {
+			return applied, err
+		}
+		if attempt > 0 {
+			return applied, fmt.Errorf("%w: overlay of zone %d", ErrNotApplied, zoneID)
+		}
+
+		applied, err = s.setOverlay(ctx, homeID, zoneID, overlay, o)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// verifyInterval and verifyTimeout are the interval at which an overlay is
+// refetched to verify it, and the time after which it is written again when
+// it was not applied.
+var (
+	verifyInterval = time.Second
+	verifyTimeout  = 3 * time.Second
+)
+
+// setOverlay writes the overlay of the zone with the given ID.
+func (s *ZoneService) setOverlay(ctx context.Context, homeID HomeID, zoneID ZoneID, overlay *Overlay, o *writeOptions) (*Overlay, error) {
+	req, err := s.client.newRequest("ZoneService", "SetOverlay", "PUT", fmt.Sprintf("homes/%d/zones/%d/overlay", homeID, zoneID), overlay, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied *Overlay
+	_, err = s.client.Do(ctx, req, &applied)
+	if err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// matches reports whether the setting s has the same power, mode and
+// temperature as the setting other.
+func (s ZoneSetting) matches(other ZoneSetting) bool {
+	if s.Power != other.Power || s.Mode != other.Mode {
+		return false
+	}
+
+	if s.Temperature == nil || other.Temperature == nil {
+		return s.Temperature == nil && other.Temperature == nil || s.Power == PowerOff
+	}
+
+	return math.Abs(s.Temperature.Celsius-other.Temperature.Celsius) < 0.05
+}
+
+// DeleteOverlay removes the overlay of the zone with the given ID of the
+// provided home ID, so that the zone follows its schedule again.
+func (s *ZoneService) DeleteOverlay(ctx context.Context, homeID HomeID, zoneID ZoneID, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "DeleteOverlay", "DELETE", fmt.Sprintf("homes/%d/zones/%d/overlay", homeID, zoneID), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetTemperature sets a heating overlay with the given temperature, in the
+// preferred unit of the client (see Client.PreferredUnit), and termination on
+// the zone with the given ID of the provided home ID.
+func (s *ZoneService) SetTemperature(ctx context.Context, homeID HomeID, zoneID ZoneID, value float64, termination Termination, opts ...WriteOption) (*Overlay, error) {
+	unit, err := s.client.PreferredUnit(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := HeatingSetting(NewTemperature(value, unit).Celsius)
+	return s.SetOverlay(ctx, homeID, zoneID, NewOverlay(setting, termination), opts...)
+}
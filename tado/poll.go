@@ -0,0 +1,76 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// ErrPollTimeout is returned by PollUntil when the predicate is not satisfied
+// before the timeout.
+var ErrPollTimeout = errors.New("poll timed out")
+
+// MinPollInterval is the minimum interval of PollUntil.
+var MinPollInterval = time.Second
+
+// PollUntil calls fetch until predicate returns true for its result, and
+// returns that result. fetch is called immediately and then every interval,
+// with up to 10% jitter so that concurrent pollers spread out. The interval is
+// raised to at least MinPollInterval and, if the client has a rate limit, the
+// interval between two tokens, so that polling cannot starve other requests.
+//
+// If the predicate is not satisfied within timeout, the last result is
+// returned together with an error wrapping ErrPollTimeout. A timeout of zero
+// polls until ctx is done. Errors of fetch are returned immediately.
+//
+// Example usage, waiting for a zone to reach 21°C:
+//
+//	state, err := tado.PollUntil(ctx, client,
+//		func(ctx context.Context) (*tado.ZoneState, error) {
+//			return client.Zone.GetState(ctx, homeID, zoneID)
+//		},
+//		func(state *tado.ZoneState) bool {
+//			t := state.SensorDataPoints.InsideTemperature
+//			return t != nil && t.Celsius >= 21
+//		},
+//		time.Minute, time.Hour)
+func PollUntil[T any](ctx context.Context, c *Client, fetch func(context.Context) (T, error), predicate func(T) bool, interval, timeout time.Duration) (T, error) {
+	interval = max(interval, MinPollInterval)
+	if c.limiter != nil {
+		if limit := c.limiter.Limit(); limit > 0 {
+			interval = max(interval, time.Duration(float64(time.Second)/float64(limit)))
+		}
+	}
+
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		v, err := fetch(ctx)
+		if err != nil {
+			return v, err
+		}
+		if predicate(v) {
+			return v, nil
+		}
+
+		wait := interval
+		if jitter := int64(interval / 10); jitter > 0 {
+			wait += time.Duration(rand.Int64N(2*jitter+1) - jitter)
+		}
+
+		select {
+		case <-ctx.Done():
+			return v, ctx.Err()
+		case <-deadline:
+			return v, fmt.Errorf("%w after %v", ErrPollTimeout, timeout)
+		case <-time.After(wait):
+		}
+	}
+}
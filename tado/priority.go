@@ -0,0 +1,174 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// Priority represents the priority of a request when the client is rate
+// limited. Higher priority requests are granted a token before lower priority
+// requests that are already waiting.
+type Priority int
+
+const (
+	// PriorityLow is meant for background pollers and watchers.
+	PriorityLow Priority = iota
+
+	// PriorityNormal is the default priority of read requests.
+	PriorityNormal
+
+	// PriorityHigh is the default priority of write requests, which are
+	// typically triggered interactively.
+	PriorityHigh
+)
+
+// priorityKey is the context key used to set the priority of requests.
+type priorityKey struct{}
+
+// WithPriority returns a RequestOption that sets the priority of the request.
+func WithPriority(priority Priority) RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(ContextWithPriority(req.Context(), priority))
+	}
+}
+
+// ContextWithPriority returns a copy of ctx that sets the priority of all
+// requests made with it, e.g. by a background poller.
+func ContextWithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+// priorityOf returns the priority of the request, which is taken from the
+// request, the context it is sent with, or its method, in that order.
+func priorityOf(ctx context.Context, req *http.Request) Priority {
+	if p, ok := req.Context().Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return p
+	}
+
+	if req.Method == "GET" || req.Method == "HEAD" {
+		return PriorityNormal
+	}
+
+	return PriorityHigh
+}
+
+// scheduler hands out the tokens of a rate limiter to waiting requests in
+// order of priority, and in FIFO order within a priority. If the limiter fails,
+// e.g. because the file of a FileLimiter cannot be locked, the requests waiting
+// at that moment fail with its error rather than being sent unlimited.
+type scheduler struct {
+	limiter tokenLimiter
+
+	mu      sync.Mutex
+	waiting [PriorityHigh + 1][]chan error
+	running bool
+
+	// cancel cancels the current wait of the dispatcher for a token. It is
+	// called when the last waiting request gives up.
+	cancel context.CancelFunc
+}
+
+// newScheduler returns a scheduler for the given limiter.
+func newScheduler(limiter tokenLimiter) *scheduler {
+	return &scheduler{limiter: limiter}
+}
+
+// wait blocks until a token is granted to a request of the given priority, or
+// ctx is done.
+func (s *scheduler) wait(ctx context.Context, priority Priority) error {
+	priority = min(max(priority, PriorityLow), PriorityHigh)
+	granted := make(chan error, 1)
+
+	s.mu.Lock()
+	s.waiting[priority] = append(s.waiting[priority], granted)
+	if !s.running {
+		s.running = true
+		go s.dispatch()
+	}
+	s.mu.Unlock()
+
+	select {
+	case err := <-granted:
+		return err
+	case <-ctx.Done():
+		s.mu.Lock()
+		if i := slices.Index(s.waiting[priority], granted); i >= 0 {
+			s.waiting[priority] = slices.Delete(s.waiting[priority], i, i+1)
+		}
+		if s.empty() && s.cancel != nil {
+			s.cancel()
+		}
+		s.mu.Unlock()
+
+		// the token may have been granted in the meantime
+		select {
+		case err := <-granted:
+			if err == nil {
+				return nil
+			}
+		default:
+		}
+		return ctx.Err()
+	}
+}
+
+// dispatch grants tokens to waiting requests until none are left.
+func (s *scheduler) dispatch() {
+	for {
+		s.mu.Lock()
+		if s.empty() {
+			s.running = false
+			s.cancel = nil
+			s.mu.Unlock()
+			return
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		s.mu.Unlock()
+
+		err := s.limiter.Wait(ctx)
+		gaveUp := err != nil && ctx.Err() != nil
+		cancel()
+
+		s.mu.Lock()
+		switch {
+		case gaveUp:
+			// all waiting requests gave up
+		case err != nil:
+			err = fmt.Errorf("rate limiter: %w", err)
+			for p := range s.waiting {
+				for _, granted := range s.waiting[p] {
+					granted <- err
+				}
+				s.waiting[p] = nil
+			}
+		default:
+			for p := PriorityHigh; p >= PriorityLow; p-- {
+				if len(s.waiting[p]) > 0 {
+					s.waiting[p][0] <- nil
+					s.waiting[p] = s.waiting[p][1:]
+					break
+				}
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+// empty reports whether no requests are waiting. s.mu must be held.
+func (s *scheduler) empty() bool {
+	for _, waiting := range s.waiting {
+		if len(waiting) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
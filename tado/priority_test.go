@@ -0,0 +1,172 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+// fakeLimiter is a tokenLimiter whose tokens are handed out by the test. Each
+// value sent on tokens completes one call to Wait with that error.
+type fakeLimiter struct {
+	tokens chan error
+
+	// cancelled receives a value when a call to Wait gives up because its
+	// context is done.
+	cancelled chan struct{}
+}
+
+func newFakeLimiter() *fakeLimiter {
+	return &fakeLimiter{tokens: make(chan error), cancelled: make(chan struct{}, 1)}
+}
+
+func (l *fakeLimiter) Wait(ctx context.Context) error {
+	select {
+	case err := <-l.tokens:
+		return err
+	case <-ctx.Done():
+		l.cancelled <- struct{}{}
+		return ctx.Err()
+	}
+}
+
+func (l *fakeLimiter) Limit() rate.Limit { return 1 }
+func (l *fakeLimiter) Burst() int        { return 1 }
+func (l *fakeLimiter) Tokens() float64   { return 0 }
+
+// waitForQueue blocks until n requests are waiting for a token of s.
+func waitForQueue(s *scheduler, n int) {
+	for {
+		s.mu.Lock()
+		queued := 0
+		for _, waiting := range s.waiting {
+			queued += len(waiting)
+		}
+		s.mu.Unlock()
+
+		if queued == n {
+			return
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestScheduler_priority(t *testing.T) {
+	limiter := newFakeLimiter()
+	s := newScheduler(limiter)
+
+	requests := []struct {
+		name     string
+		priority Priority
+	}{
+		{"low 1", PriorityLow},
+		{"normal", PriorityNormal},
+		{"high 1", PriorityHigh},
+		{"low 2", PriorityLow},
+		{"high 2", PriorityHigh},
+	}
+
+	granted := make(chan string)
+	for i, r := range requests {
+		go func() {
+			if err := s.wait(context.Background(), r.priority); err != nil {
+				t.Errorf("%s: unexpected error: %v", r.name, err)
+			}
+			granted <- r.name
+		}()
+
+		// queue the requests in order while the limiter is saturated
+		waitForQueue(s, i+1)
+	}
+
+	want := []string{"high 1", "high 2", "normal", "low 1", "low 2"}
+	for _, name := range want {
+		limiter.tokens <- nil
+		if got := <-granted; got != name {
+			t.Fatalf("granted a token to %s, want %s", got, name)
+		}
+	}
+}
+
+func TestScheduler_cancel(t *testing.T) {
+	limiter := newFakeLimiter()
+	s := newScheduler(limiter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		cancelled <- s.wait(ctx, PriorityHigh)
+	}()
+	waitForQueue(s, 1)
+
+	granted := make(chan error)
+	go func() {
+		granted <- s.wait(context.Background(), PriorityLow)
+	}()
+	waitForQueue(s, 2)
+
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	// the cancelled request released its place, so the next token goes to
+	// the request behind it
+	waitForQueue(s, 1)
+	limiter.tokens <- nil
+	if err := <-granted; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestScheduler_cancelLast(t *testing.T) {
+	limiter := newFakeLimiter()
+	s := newScheduler(limiter)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.wait(ctx, PriorityNormal)
+	}()
+	waitForQueue(s, 1)
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got error %v, want %v", err, context.Canceled)
+	}
+
+	// the dispatcher stops waiting for a token nobody wants anymore
+	<-limiter.cancelled
+
+	go func() {
+		done <- s.wait(context.Background(), PriorityNormal)
+	}()
+	limiter.tokens <- nil
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestScheduler_limiterError(t *testing.T) {
+	limiter := newFakeLimiter()
+	s := newScheduler(limiter)
+
+	done := make(chan error)
+	for i := range 2 {
+		go func() {
+			done <- s.wait(context.Background(), PriorityNormal)
+		}()
+		waitForQueue(s, i+1)
+	}
+
+	errLocked := errors.New("locking rate limit file")
+	limiter.tokens <- errLocked
+	for range 2 {
+		if err := <-done; !errors.Is(err, errLocked) {
+			t.Errorf("got error %v, want %v", err, errLocked)
+		}
+	}
+}
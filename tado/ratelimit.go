@@ -0,0 +1,52 @@
+package tado
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterKey is the context key used to override the rate limiter of a
+// request.
+type rateLimiterKey struct{}
+
+// WithRateLimit makes the client limit its requests to rps requests per
+// second, with bursts of up to burst requests, using a token bucket. Requests
+// wait for a token before they are sent, which prevents long-running daemons
+// from exceeding the Tado API quotas.
+//
+// Waiting requests are granted tokens in order of their Priority, so that
+// interactive writes pre-empt background polling under rate pressure.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+		c.scheduler = newScheduler(c.limiter)
+	}
+}
+
+// WithRequestRateLimiter returns a RequestOption that makes the request wait
+// for the given limiter instead of the rate limiter of the client. A nil
+// limiter sends the request without rate limiting.
+func WithRequestRateLimiter(limiter *rate.Limiter) RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(context.WithValue(req.Context(), rateLimiterKey{}, limiter))
+	}
+}
+
+// waitRateLimit waits until the request may be sent according to its rate
+// limiter and priority.
+func (c *Client) waitRateLimit(ctx context.Context, req *http.Request) error {
+	if l, ok := req.Context().Value(rateLimiterKey{}).(*rate.Limiter); ok {
+		if l == nil {
+			return nil
+		}
+		return l.Wait(ctx)
+	}
+
+	if c.scheduler == nil {
+		return nil
+	}
+
+	return c.scheduler.wait(ctx, priorityOf(ctx, req))
+}
@@ -0,0 +1,132 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// countingTransport returns a transport that answers all requests with an
+// empty object and counts them.
+func countingTransport(requests *atomic.Int32) roundTripperFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		return newTestResponse(req, http.StatusOK, "{}"), nil
+	}
+}
+
+// get sends a GET request for the current user using client.
+func get(ctx context.Context, client *Client, opts ...RequestOption) error {
+	req, err := client.NewRequest("GET", "me", nil, opts...)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+func TestWithRateLimit_burst(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(countingTransport(&requests), WithRateLimit(1e-9, 3))
+
+	for range 3 {
+		if err := get(context.Background(), client); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("got %d requests, want 3", got)
+	}
+	if got := client.limiter.Tokens(); got >= 1 {
+		t.Errorf("got %v tokens left, want none", got)
+	}
+}
+
+func TestWithRateLimit_wait(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(countingTransport(&requests), WithRateLimit(1e-9, 1), WithTimeouts(TimeoutProfile{}))
+
+	if err := get(context.Background(), client); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- get(ctx, client)
+	}()
+
+	// the waiting request has reserved the next token
+	for client.limiter.Tokens() > -0.5 {
+		select {
+		case err := <-done:
+			t.Fatalf("got error %v before the request was cancelled", err)
+		default:
+			runtime.Gosched()
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests while waiting, want 1", got)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want %v", err, context.Canceled)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("got %d requests, want 1", got)
+	}
+}
+
+func TestWithRequestRateLimiter(t *testing.T) {
+	tests := []struct {
+		name        string
+		limiter     *rate.Limiter
+		wantRequest bool
+	}{
+		{"without limiter", nil, true},
+		{"with tokens", rate.NewLimiter(1e-9, 1), true},
+		{"without tokens", rate.NewLimiter(1e-9, 0), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests atomic.Int32
+			client := newTestClient(countingTransport(&requests), WithRateLimit(1e-9, 1))
+
+			// drain the rate limiter of the client
+			if err := get(context.Background(), client); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// a request that has to wait fails at once, as the next token is
+			// not available before the deadline
+			ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+			defer cancel()
+
+			err := get(ctx, client, WithRequestRateLimiter(tt.limiter))
+			if tt.wantRequest && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantRequest && err == nil {
+				t.Fatal("got no error, want the request to exceed its deadline")
+			}
+
+			want := int32(1)
+			if tt.wantRequest {
+				want = 2
+			}
+			if got := requests.Load(); got != want {
+				t.Errorf("got %d requests, want %d", got, want)
+			}
+		})
+	}
+}
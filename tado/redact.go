@@ -0,0 +1,92 @@
+package tado
+
+import "reflect"
+
+// redacted replaces redacted non-empty strings.
+const redacted = "[REDACTED]"
+
+// Redact returns a deep copy of v with all personally identifiable
+// information, such as names, emails, addresses, geolocations and serial
+// numbers, masked, so that models can be logged safely.
+//
+// Fields are masked if they are tagged with `redact:"true"`; tagging a struct
+// field masks everything it contains. Masked strings are replaced by
+// "[REDACTED]" and masked numbers are zeroed.
+func Redact[T any](v T) T {
+	src := reflect.ValueOf(&v).Elem()
+	dst := reflect.New(src.Type()).Elem()
+	redactValue(dst, src, false)
+
+	return dst.Interface().(T)
+}
+
+// redactValue deep copies src into dst, masking all values if mask is true.
+func redactValue(dst, src reflect.Value, mask bool) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		redactValue(p.Elem(), src.Elem(), mask)
+		dst.Set(p)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		e := reflect.New(src.Elem().Type()).Elem()
+		redactValue(e, src.Elem(), mask)
+		dst.Set(e)
+	case reflect.Struct:
+		// copy the struct as a whole first to retain unexported fields
+		dst.Set(src)
+		for i := range src.NumField() {
+			field := src.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			redactValue(dst.Field(i), src.Field(i), mask || field.Tag.Get("redact") == "true")
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := range src.Len() {
+			redactValue(s.Index(i), src.Index(i), mask)
+		}
+		dst.Set(s)
+	case reflect.Array:
+		for i := range src.Len() {
+			redactValue(dst.Index(i), src.Index(i), mask)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			e := reflect.New(src.Type().Elem()).Elem()
+			redactValue(e, iter.Value(), mask)
+			m.SetMapIndex(iter.Key(), e)
+		}
+		dst.Set(m)
+	case reflect.String:
+		if mask && src.Len() > 0 {
+			dst.SetString(redacted)
+			return
+		}
+		dst.Set(src)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if mask {
+			dst.SetZero()
+			return
+		}
+		dst.Set(src)
+	default:
+		dst.Set(src)
+	}
+}
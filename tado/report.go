@@ -0,0 +1,75 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ReportService handles historic data assembled from the day reports of the
+// Tado API.
+type ReportService service
+
+// OutsideTemperatureHistory returns the outside temperature of the home with
+// the given ID for every day from from up to and including to, ordered by
+// time. The live weather endpoint has no history, so it is assembled from the
+// weather slots of the day reports of the first heating zone of the home.
+func (s *ReportService) OutsideTemperatureHistory(ctx context.Context, homeID HomeID, from, to time.Time) ([]DataPoint[Temperature], error) {
+	home, err := s.client.Home.Get(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(home.DateTimeZone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	zones, err := s.client.Zone.List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(zones, func(zone Zone) bool { return zone.Type == ZoneTypeHeating })
+	if i < 0 {
+		return nil, fmt.Errorf("home %d has no heating zone", homeID)
+	}
+
+	var history []DataPoint[Temperature]
+	for report, err := range s.client.Zone.DayReports(ctx, homeID, zones[i].ID, from, to) {
+		if err != nil {
+			return nil, err
+		}
+
+		history = append(history, weatherSlots(report, loc)...)
+	}
+
+	return history, nil
+}
+
+// weatherSlots returns the outside temperatures of the weather slots of the
+// given day report, ordered by time. Slots are keyed by their local time of
+// day, e.g. "16:00", in the given location.
+func weatherSlots(report *DayReport, loc *time.Location) []DataPoint[Temperature] {
+	y, m, d := report.Interval.From.In(loc).Date()
+
+	var points []DataPoint[Temperature]
+	for key, slot := range report.Weather.Slots.Slots {
+		t, err := time.Parse("15:04", key)
+		if err != nil {
+			continue
+		}
+
+		points = append(points, DataPoint[Temperature]{
+			Timestamp: time.Date(y, m, d, t.Hour(), t.Minute(), 0, 0, loc),
+			Value:     slot.Temperature,
+		})
+	}
+
+	slices.SortFunc(points, func(a, b DataPoint[Temperature]) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	return points
+}
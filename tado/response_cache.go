@@ -0,0 +1,294 @@
+package tado
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// CacheConfig configures the caching of the responses of slowly changing
+// resources, see WithCache. Each field is the time the responses of a class
+// of resources are cached for; zero disables caching them.
+type CacheConfig struct {
+	// Home is the TTL of the details of a home (HomeService.Get).
+	Home time.Duration
+
+	// Zones is the TTL of the zone list of a home (ZoneService.List).
+	Zones time.Duration
+
+	// Devices is the TTL of the devices of a home (DeviceService.List and
+	// DeviceService.GetDeviceList).
+	Devices time.Duration
+
+	// Capabilities is the TTL of the capabilities of a zone
+	// (ZoneService.GetCapabilities). The capabilities of a home are always
+	// cached, see Client.Capabilities.
+	Capabilities time.Duration
+}
+
+// DefaultCacheConfig is a CacheConfig suited for dashboards that poll
+// frequently.
+var DefaultCacheConfig = CacheConfig{
+	Home:         time.Hour,
+	Zones:        time.Hour,
+	Devices:      5 * time.Minute,
+	Capabilities: 24 * time.Hour,
+}
+
+// WithCache makes the client cache the responses of slowly changing resources
+// in its cache (see WithCacheStore) for the TTLs of config. The cached
+// responses of a home are invalidated when the client writes to the home, and
+// can be invalidated explicitly using Client.InvalidateCache.
+//
+// The responses are cached per user, identified by the ID of the
+// authenticated user, which is requested once before the first cached
+// response, so clients of different accounts sharing a cache never see each
+// other's responses. When a cache is shared between processes, a write
+// invalidates the home, zone list and device responses of the home stored by
+// any of them, but the zone capabilities only if this process stored them;
+// those cached by other processes expire after their TTL.
+func WithCache(config CacheConfig) ClientOption {
+	return func(c *Client) {
+		c.responseCache = &responseCache{config: config, keys: map[HomeID]map[string]bool{}}
+	}
+}
+
+// responseCache holds the configuration of the response cache of a client and
+// the keys of the cached responses of each home.
+type responseCache struct {
+	config CacheConfig
+
+	mu   sync.Mutex
+	keys map[HomeID]map[string]bool
+}
+
+// cachedResourcePattern matches the paths of the resources that can be cached
+// and captures the home ID and the class of the resource.
+var cachedResourcePattern = regexp.MustCompile(`/homes/(\d+)(|/zones|/devices|/deviceList|/zones/\d+/capabilities)$`)
+
+// ttl returns the TTL and the home of the resource requested by req, or zero
+// if the resource is not cached.
+func (rc *responseCache) ttl(req *http.Request) (time.Duration, HomeID) {
+	if req.Method != http.MethodGet {
+		return 0, 0
+	}
+
+	m := cachedResourcePattern.FindStringSubmatch(req.URL.Path)
+	if m == nil {
+		return 0, 0
+	}
+	id, _ := strconv.Atoi(m[1])
+
+	switch m[2] {
+	case "":
+		return rc.config.Home, HomeID(id)
+	case "/zones":
+		return rc.config.Zones, HomeID(id)
+	case "/devices", "/deviceList":
+		return rc.config.Devices, HomeID(id)
+	default:
+		return rc.config.Capabilities, HomeID(id)
+	}
+}
+
+// scopeLookupKey is the context key used to mark the request cacheScope sends
+// to look up the authenticated user, which is never cached.
+type scopeLookupKey struct{}
+
+// cacheScope returns the ID of the authenticated user, which prefixes the keys
+// of the cached responses and recorded ETags. It is requested once.
+func (c *Client) cacheScope(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	userID := c.userID
+	c.mu.Unlock()
+	if userID != "" {
+		return userID, nil
+	}
+
+	user, err := c.User.Get(context.WithValue(ctx, scopeLookupKey{}, true))
+	if err != nil {
+		return "", err
+	}
+	if user.ID == "" {
+		return "", errors.New("authenticated user has no ID")
+	}
+
+	c.mu.Lock()
+	c.userID = user.ID
+	c.mu.Unlock()
+
+	return user.ID, nil
+}
+
+// responseKey returns the key of the cached response to a request of the
+// given URL by the given user.
+func responseKey(userID string, u *url.URL) string {
+	return "responses/" + userID + "/" + u.Host + u.RequestURI()
+}
+
+// homeKeys returns the keys of the cached responses of the given home that do
+// not depend on its zones, so that they can be invalidated even if they were
+// stored by another process.
+func (c *Client) homeKeys(userID string, homeID HomeID) []string {
+	var keys []string
+	for _, suffix := range []string{"", "/zones", "/devices", "/deviceList"} {
+		u := c.baseURL.ResolveReference(&url.URL{Path: fmt.Sprintf("homes/%d%s", homeID, suffix)})
+		keys = append(keys, responseKey(userID, u))
+	}
+
+	return keys
+}
+
+// track records that the response stored under key belongs to the given
+// home.
+func (rc *responseCache) track(homeID HomeID, key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if rc.keys[homeID] == nil {
+		rc.keys[homeID] = map[string]bool{}
+	}
+	rc.keys[homeID][key] = true
+}
+
+// untrack returns and forgets the keys of the cached responses of the given
+// home.
+func (rc *responseCache) untrack(homeID HomeID) []string {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	keys := make([]string, 0, len(rc.keys[homeID]))
+	for key := range rc.keys[homeID] {
+		keys = append(keys, key)
+	}
+	delete(rc.keys, homeID)
+
+	return keys
+}
+
+// doCached implements Do for requests of resources that are cached. v must
+// not be nil or an io.Writer. If the authenticated user cannot be
+// determined, the request is sent without caching.
+func (c *Client) doCached(ctx context.Context, req *http.Request, v any, ttl time.Duration, homeID HomeID) (*Response, error) {
+	userID, err := c.cacheScope(ctx)
+	if err != nil {
+		return c.do(ctx, req, v)
+	}
+	key := responseKey(userID, req.URL)
+
+	if data, ok, err := c.cache.Get(ctx, key); err == nil && ok {
+		if err := json.Unmarshal(data, v); err == nil {
+			return &Response{Response: &http.Response{
+				Status:     "200 OK",
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Content-Type": {"application/json"}},
+				Body:       http.NoBody,
+				Request:    req,
+			}}, nil
+		}
+	}
+
+	var buf bytes.Buffer
+	res, err := c.do(ctx, req, &buf)
+	if err != nil {
+		return res, err
+	}
+
+	if err := json.Unmarshal(buf.Bytes(), v); err != nil && buf.Len() > 0 {
+		return res, err
+	}
+
+	if c.cache.Set(ctx, key, buf.Bytes(), ttl) == nil {
+		c.responseCache.track(homeID, key)
+	}
+
+	return res, nil
+}
+
+// InvalidateCache removes the cached responses and capabilities of the home
+// with the given ID, see WithCache.
+func (c *Client) InvalidateCache(ctx context.Context, homeID HomeID) error {
+	var errs []error
+	if c.responseCache != nil {
+		for _, key := range c.responseKeys(ctx, homeID) {
+			errs = append(errs, c.cache.Delete(ctx, key))
+		}
+	}
+	errs = append(errs, c.InvalidateCapabilities(ctx, homeID))
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalidating cache of home %d: %w", homeID, err)
+	}
+
+	return nil
+}
+
+// invalidateAfterWrite invalidates the cached responses of the home req was
+// written to, if any.
+func (c *Client) invalidateAfterWrite(ctx context.Context, req *http.Request) {
+	if c.responseCache == nil || req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return
+	}
+
+	m := homePathPattern.FindStringSubmatch(req.URL.Path)
+	if m == nil {
+		return
+	}
+	id, _ := strconv.Atoi(m[1])
+
+	for _, key := range c.responseKeys(ctx, HomeID(id)) {
+		_ = c.cache.Delete(ctx, key)
+	}
+}
+
+// responseKeys returns and forgets the keys of the cached responses of the
+// given home, including those that may have been stored by other processes.
+func (c *Client) responseKeys(ctx context.Context, homeID HomeID) []string {
+	keys := c.responseCache.untrack(homeID)
+	if userID, err := c.cacheScope(ctx); err == nil {
+		for _, key := range c.homeKeys(userID, homeID) {
+			if !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// bypassCacheKey is the context key of requests that bypass the response
+// cache.
+type bypassCacheKey struct{}
+
+// bypassCache returns a RequestOption that makes the request bypass the
+// response cache, e.g. to read a resource before modifying it.
+func bypassCache() RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(context.WithValue(req.Context(), bypassCacheKey{}, true))
+	}
+}
+
+// cacheTTL returns the TTL and the home of the response of req, decoded into
+// v, or zero if it is not cached.
+func (c *Client) cacheTTL(req *http.Request, v any) (time.Duration, HomeID) {
+	if c.responseCache == nil || req.Context().Value(bypassCacheKey{}) != nil {
+		return 0, 0
+	}
+
+	switch v.(type) {
+	case nil, io.Writer:
+		return 0, 0
+	}
+
+	return c.responseCache.ttl(req)
+}
@@ -0,0 +1,151 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+// BackoffFunc returns the time to wait before the given retry attempt, which
+// starts at 1 for the first retry.
+type BackoffFunc func(attempt int) time.Duration
+
+// retryConfig holds the retry configuration of a Client.
+type retryConfig struct {
+	max           int
+	backoff       BackoffFunc
+	nonIdempotent bool
+}
+
+// ExponentialBackoff returns a BackoffFunc that waits a random duration
+// between zero and base*2^(attempt-1), capped at maxDelay ("full jitter").
+func ExponentialBackoff(base, maxDelay time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		d := maxDelay
+		if attempt < 32 {
+			if exp := base << (attempt - 1); exp > 0 && exp < maxDelay {
+				d = exp
+			}
+		}
+
+		return rand.N(d + 1)
+	}
+}
+
+// WithRetry makes the client retry requests up to maxRetries times when they
+// fail with 429 Too Many Requests, 502 Bad Gateway, 503 Service Unavailable or
+// a network timeout. The time to wait between attempts is taken from the
+// Retry-After header if present, or from backoff otherwise. If backoff is
+// nil, ExponentialBackoff(500*time.Millisecond, 30*time.Second) is used.
+//
+// Requests with a non-idempotent method, such as POST, are only retried on
+// 429 Too Many Requests, which the API answers without processing the
+// request, as they may have been processed before a timeout or a 502 or 503
+// response. Use WithRetryNonIdempotent to retry them on all these errors.
+func WithRetry(maxRetries int, backoff BackoffFunc) ClientOption {
+	return func(c *Client) {
+		if backoff == nil {
+			backoff = ExponentialBackoff(500*time.Millisecond, 30*time.Second)
+		}
+
+		nonIdempotent := c.retry != nil && c.retry.nonIdempotent
+		c.retry = &retryConfig{max: maxRetries, backoff: backoff, nonIdempotent: nonIdempotent}
+	}
+}
+
+// WithRetryNonIdempotent makes the client configured with WithRetry retry
+// requests with non-idempotent methods, such as POST, on all retryable
+// errors, at the risk of e.g. sending a push notification twice.
+func WithRetryNonIdempotent() ClientOption {
+	return func(c *Client) {
+		if c.retry == nil {
+			c.retry = &retryConfig{}
+		}
+		c.retry.nonIdempotent = true
+	}
+}
+
+// send sends the request using BareDo and checks the response for API errors,
+// retrying according to the retry configuration of the client. On success,
+// the caller must close the response body.
+func (c *Client) send(ctx context.Context, req *http.Request) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		res, err := c.BareDo(ctx, req)
+		if err == nil {
+			err = CheckResponse(res.Response)
+		}
+		if err == nil {
+			return res, nil
+		}
+
+		if c.retry == nil || attempt >= c.retry.max || !c.retry.retryable(ctx, req, err) {
+			return res, err
+		}
+
+		wait := c.retry.wait(attempt+1, err)
+		c.logRetry(ctx, req, attempt+1, wait, err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res, err
+		case <-timer.C:
+		}
+	}
+}
+
+// wait returns the time to wait before the given retry attempt of a request
+// that failed with err.
+func (rc *retryConfig) wait(attempt int, err error) time.Duration {
+	var rateLimitError *RateLimitError
+	if errors.As(err, &rateLimitError) && rateLimitError.RetryAfter > 0 {
+		return rateLimitError.RetryAfter
+	}
+
+	return rc.backoff(attempt)
+}
+
+// retryable reports whether req, which failed with err, should be retried.
+func (rc *retryConfig) retryable(ctx context.Context, req *http.Request, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if IsRateLimited(err) {
+		return true
+	}
+
+	if !rc.nonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+
+	if hasStatus(err, http.StatusBadGateway) || hasStatus(err, http.StatusServiceUnavailable) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// isIdempotent reports whether requests with the given method can safely be
+// sent more than once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
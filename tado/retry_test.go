@@ -0,0 +1,194 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// noBackoff is a BackoffFunc that retries immediately.
+func noBackoff(int) time.Duration {
+	return 0
+}
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestClient_send_retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		faults       []int // status of the failed attempts, 0 for a network timeout
+		opts         []ClientOption
+		wantStatus   int
+		wantRequests int
+	}{
+		{
+			name:         "rate limited GET is retried",
+			method:       "GET",
+			faults:       []int{http.StatusTooManyRequests, http.StatusTooManyRequests},
+			opts:         []ClientOption{WithRetry(2, noBackoff)},
+			wantRequests: 3,
+		},
+		{
+			name:         "unavailable GET is retried",
+			method:       "GET",
+			faults:       []int{http.StatusServiceUnavailable},
+			opts:         []ClientOption{WithRetry(2, noBackoff)},
+			wantRequests: 2,
+		},
+		{
+			name:         "network timeout is retried",
+			method:       "GET",
+			faults:       []int{0},
+			opts:         []ClientOption{WithRetry(2, noBackoff)},
+			wantRequests: 2,
+		},
+		{
+			name:         "retries are exhausted",
+			method:       "GET",
+			faults:       []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			opts:         []ClientOption{WithRetry(2, noBackoff)},
+			wantStatus:   http.StatusBadGateway,
+			wantRequests: 3,
+		},
+		{
+			name:         "internal server error is not retried",
+			method:       "GET",
+			faults:       []int{http.StatusInternalServerError},
+			opts:         []ClientOption{WithRetry(2, noBackoff)},
+			wantStatus:   http.StatusInternalServerError,
+			wantRequests: 1,
+		},
+		{
+			name:         "without retry",
+			method:       "GET",
+			faults:       []int{http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "rate limited POST is retried with its body",
+			method:       "POST",
+			faults:       []int{http.StatusTooManyRequests},
+			opts:         []ClientOption{WithRetry(2, noBackoff)},
+			wantRequests: 2,
+		},
+		{
+			name:         "unavailable POST is not retried",
+			method:       "POST",
+			faults:       []int{http.StatusServiceUnavailable},
+			opts:         []ClientOption{WithRetry(2, noBackoff)},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantRequests: 1,
+		},
+		{
+			name:         "unavailable POST is retried if non-idempotent retries are enabled",
+			method:       "POST",
+			faults:       []int{http.StatusServiceUnavailable},
+			opts:         []ClientOption{WithRetry(2, noBackoff), WithRetryNonIdempotent()},
+			wantRequests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := newTestClient(func(req *http.Request) (*http.Response, error) {
+				requests++
+
+				if req.Body != nil {
+					if body, _ := io.ReadAll(req.Body); string(body) != "{\"name\":\"x\"}\n" {
+						t.Errorf("attempt %d sent body %q", requests, body)
+					}
+				}
+
+				if requests > len(tt.faults) {
+					return newTestResponse(req, http.StatusOK, "{}"), nil
+				}
+				if status := tt.faults[requests-1]; status != 0 {
+					return newTestResponse(req, status, ""), nil
+				}
+				return nil, timeoutError{}
+			}, tt.opts...)
+
+			var body any
+			if tt.method == "POST" {
+				body = map[string]string{"name": "x"}
+			}
+			req, err := client.NewRequest(tt.method, "me", body)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = client.Do(context.Background(), req, nil)
+
+			var errorResponse *ErrorResponse
+			switch {
+			case tt.wantStatus == 0 && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tt.wantStatus != 0 && !errors.As(err, &errorResponse):
+				t.Fatalf("got error %v, want status %d", err, tt.wantStatus)
+			case tt.wantStatus != 0 && errorResponse.Response.StatusCode != tt.wantStatus:
+				t.Fatalf("got status %d, want %d", errorResponse.Response.StatusCode, tt.wantStatus)
+			}
+
+			if requests != tt.wantRequests {
+				t.Errorf("got %d requests, want %d", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_wait(t *testing.T) {
+	rc := &retryConfig{backoff: func(attempt int) time.Duration {
+		return time.Duration(attempt) * time.Second
+	}}
+
+	tests := []struct {
+		name    string
+		attempt int
+		err     error
+		want    time.Duration
+	}{
+		{"backoff", 2, &ErrorResponse{}, 2 * time.Second},
+		{"Retry-After", 2, &RateLimitError{RetryAfter: time.Minute}, time.Minute},
+		{"rate limited without Retry-After", 3, &RateLimitError{}, 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := rc.wait(tt.attempt, tt.err); got != tt.want {
+			t.Errorf("%s: wait(%d) = %v, want %v", tt.name, tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{64, time.Second},
+	}
+
+	for _, tt := range tests {
+		for range 100 {
+			if got := backoff(tt.attempt); got < 0 || got > tt.max {
+				t.Fatalf("backoff(%d) = %v, want between 0 and %v", tt.attempt, got, tt.max)
+			}
+		}
+	}
+}
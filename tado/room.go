@@ -0,0 +1,219 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// DefaultHopsURL is the base URL of the API of Tado X homes ("hops").
+const DefaultHopsURL = "https://hops.tado.com/"
+
+// RoomService handles communication with the room-related methods of the
+// Tado X API. Tado X homes, whose Home.Generation is GenerationLineX (see
+// Capabilities.TadoX), have rooms instead of zones; their requests are sent
+// to DefaultHopsURL, or the URL set with WithHopsURL.
+type RoomService service
+
+// WithHopsURL sets the base URL of the Tado X API, e.g. to point the client
+// at a mock server. A trailing slash is added if missing. By default,
+// DefaultHopsURL is used.
+func WithHopsURL(hopsURL string) ClientOption {
+	return func(c *Client) {
+		c.hopsURL = c.parseServiceURL("hops", hopsURL)
+	}
+}
+
+// IsTadoX reports whether the home is a Tado X home, whose rooms are
+// controlled using the RoomService instead of the ZoneService.
+func (h *Home) IsTadoX() bool {
+	return h.Generation == GenerationLineX
+}
+
+// RoomTemperature is a temperature in the Tado X API, in degrees Celsius.
+type RoomTemperature struct {
+	Value float64 `json:"value"`
+}
+
+// RoomSetting represents the setting of a room, e.g. heating at 21°C.
+// Temperature is nil when the room is switched off.
+type RoomSetting struct {
+	Power       Power            `json:"power"`
+	Temperature *RoomTemperature `json:"temperature,omitempty"`
+}
+
+// RoomTermination represents the remaining time of the manual control or
+// boost of a room.
+type RoomTermination struct {
+	Type                   TerminationType `json:"type"`
+	RemainingTimeInSeconds int             `json:"remainingTimeInSeconds,omitempty"`
+	ProjectedExpiry        *time.Time      `json:"projectedExpiry,omitempty"`
+}
+
+// Room represents a room of a Tado X home and its current state.
+type Room struct {
+	ID               RoomID `json:"id"`
+	Name             string `json:"name"`
+	SensorDataPoints struct {
+		InsideTemperature *RoomTemperature `json:"insideTemperature,omitempty"`
+		Humidity          *struct {
+			Percentage float64 `json:"percentage"`
+		} `json:"humidity,omitempty"`
+	} `json:"sensorDataPoints"`
+	Setting                  RoomSetting      `json:"setting"`
+	ManualControlTermination *RoomTermination `json:"manualControlTermination,omitempty"`
+	BoostMode                *RoomTermination `json:"boostMode,omitempty"`
+	HeatingPower             *struct {
+		Percentage int `json:"percentage"`
+	} `json:"heatingPower,omitempty"`
+	Connection struct {
+		State string `json:"state"`
+	} `json:"connection"`
+	OpenWindow *struct {
+		Activated       bool `json:"activated"`
+		ExpiryInSeconds int  `json:"expiryInSeconds"`
+	} `json:"openWindow,omitempty"`
+	NextScheduleChange *struct {
+		Start   time.Time   `json:"start"`
+		Setting RoomSetting `json:"setting"`
+	} `json:"nextScheduleChange,omitempty"`
+}
+
+// hopsPath returns the URL of the given path of the Tado X API.
+func (s *RoomService) hopsPath(format string, args ...any) string {
+	return s.client.hopsURL.String() + fmt.Sprintf(format, args...)
+}
+
+// List returns all rooms of the Tado X home with the given ID, including
+// their state.
+func (s *RoomService) List(ctx context.Context, homeID HomeID) ([]Room, error) {
+	req, err := s.client.newRequest("RoomService", "List", "GET", s.hopsPath("homes/%d/rooms", homeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var rooms []Room
+	_, err = s.client.Do(ctx, req, &rooms)
+	if err != nil {
+		return nil, err
+	}
+
+	return rooms, nil
+}
+
+// All returns an iterator over all rooms of the Tado X home with the given
+// ID. The rooms are fetched when the iteration starts.
+func (s *RoomService) All(ctx context.Context, homeID HomeID) iter.Seq2[Room, error] {
+	return seq(func() ([]Room, error) {
+		return s.List(ctx, homeID)
+	})
+}
+
+// Get returns the room with the given ID of the provided Tado X home ID,
+// including its state.
+func (s *RoomService) Get(ctx context.Context, homeID HomeID, roomID RoomID) (*Room, error) {
+	req, err := s.client.newRequest("RoomService", "Get", "GET", s.hopsPath("homes/%d/rooms/%d", homeID, roomID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var room *Room
+	_, err = s.client.Do(ctx, req, &room)
+	if err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// SetManualControl overrides the schedule of the room with the given ID of
+// the provided Tado X home ID with the given setting until the termination,
+// which must be a manual, timer or next time block termination.
+func (s *RoomService) SetManualControl(ctx context.Context, homeID HomeID, roomID RoomID, setting RoomSetting, termination Termination, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	body := struct {
+		Setting     RoomSetting `json:"setting"`
+		Termination struct {
+			Type              TerminationType `json:"type"`
+			DurationInSeconds int             `json:"durationInSeconds,omitempty"`
+		} `json:"termination"`
+	}{Setting: setting}
+	body.Termination.Type = termination.Type
+	body.Termination.DurationInSeconds = termination.DurationInSeconds
+
+	req, err := s.client.newRequest("RoomService", "SetManualControl", "POST", s.hopsPath("homes/%d/rooms/%d/manualControl", homeID, roomID), &body, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetTemperature heats the room with the given ID of the provided Tado X home
+// ID to the given temperature, in the preferred unit of the client (see
+// Client.PreferredUnit), until the termination.
+func (s *RoomService) SetTemperature(ctx context.Context, homeID HomeID, roomID RoomID, value float64, termination Termination, opts ...WriteOption) error {
+	unit, err := s.client.PreferredUnit(ctx, homeID)
+	if err != nil {
+		return err
+	}
+
+	setting := RoomSetting{
+		Power:       PowerOn,
+		Temperature: &RoomTemperature{Value: NewTemperature(value, unit).Celsius},
+	}
+	return s.SetManualControl(ctx, homeID, roomID, setting, termination, opts...)
+}
+
+// ResumeSchedule ends the manual control of the room with the given ID of the
+// provided Tado X home ID, so that it follows its schedule again.
+func (s *RoomService) ResumeSchedule(ctx context.Context, homeID HomeID, roomID RoomID, opts ...WriteOption) error {
+	return s.send(ctx, "ResumeSchedule", "DELETE", s.hopsPath("homes/%d/rooms/%d/manualControl", homeID, roomID), opts)
+}
+
+// Boost heats the room with the given ID of the provided Tado X home ID at
+// full power for a short period.
+func (s *RoomService) Boost(ctx context.Context, homeID HomeID, roomID RoomID, opts ...WriteOption) error {
+	return s.send(ctx, "Boost", "POST", s.hopsPath("homes/%d/rooms/%d/boost", homeID, roomID), opts)
+}
+
+// BoostAll boosts all rooms of the Tado X home with the given ID.
+func (s *RoomService) BoostAll(ctx context.Context, homeID HomeID, opts ...WriteOption) error {
+	return s.send(ctx, "BoostAll", "POST", s.hopsPath("homes/%d/quickActions/boost", homeID), opts)
+}
+
+// TurnOffAll switches off all rooms of the Tado X home with the given ID.
+func (s *RoomService) TurnOffAll(ctx context.Context, homeID HomeID, opts ...WriteOption) error {
+	return s.send(ctx, "TurnOffAll", "POST", s.hopsPath("homes/%d/quickActions/allOff", homeID), opts)
+}
+
+// ResumeAllSchedules ends the manual control of all rooms of the Tado X home
+// with the given ID.
+func (s *RoomService) ResumeAllSchedules(ctx context.Context, homeID HomeID, opts ...WriteOption) error {
+	return s.send(ctx, "ResumeAllSchedules", "POST", s.hopsPath("homes/%d/quickActions/resumeSchedule", homeID), opts)
+}
+
+// send sends a write request without body to the given URL for the given
+// method of the service.
+func (s *RoomService) send(ctx context.Context, name, method, u string, opts []WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("RoomService", name, method, u, nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package tado
+
+import (
+	"context"
+	"time"
+)
+
+// RunningTimes represents how long the boiler of a home was running.
+type RunningTimes struct {
+	RunningTimes []struct {
+		StartTime            string `json:"startTime"`
+		EndTime              string `json:"endTime"`
+		RunningTimeInSeconds int    `json:"runningTimeInSeconds"`
+		Zones                []struct {
+			ID                   ZoneID `json:"id"`
+			RunningTimeInSeconds int    `json:"runningTimeInSeconds"`
+		} `json:"zones"`
+	} `json:"runningTimes"`
+	Summary struct {
+		StartTime                 string  `json:"startTime"`
+		EndTime                   string  `json:"endTime"`
+		MeanInSecondsPerDay       float64 `json:"meanInSecondsPerDay"`
+		TotalRunningTimeInSeconds int     `json:"totalRunningTimeInSeconds"`
+	} `json:"summary"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// ZoneAttribution is the share of the boiler running time attributed to a
+// zone.
+type ZoneAttribution struct {
+	ZoneID      ZoneID        `json:"zoneId"`
+	ZoneName    string        `json:"zoneName"`
+	Share       float64       `json:"share"`
+	RunningTime time.Duration `json:"runningTime"`
+}
+
+// GetRunningTimes returns the daily boiler running times of the home with the
+// given ID from from up to and including to.
+func (s *EnergyIQService) GetRunningTimes(ctx context.Context, homeID HomeID, from, to time.Time) (*RunningTimes, error) {
+	path := s.client.minderPath("homes/%d/runningTimes?from=%s&to=%s&aggregate=day&summary_only=false",
+		homeID, from.Format(time.DateOnly), to.Format(time.DateOnly))
+	req, err := s.client.newRequest("EnergyIQService", "GetRunningTimes", "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var runningTimes *RunningTimes
+	_, err = s.client.Do(ctx, req, &runningTimes)
+	if err != nil {
+		return nil, err
+	}
+
+	return runningTimes, nil
+}
+
+// ZoneAttribution estimates the share of the boiler running time of the home
+// with the given ID during the month of the given date attributable to each
+// heating zone, e.g. to split a heating bill between the tenants of a shared
+// house. Each zone's share is its own running time, as reported with the
+// running times of the home, weighted by its heating power relative to that
+// of all zones.
+//
+// power holds the heating power of the zones, e.g. the rated output of their
+// radiators in watts. Zones missing from it are weighted with the mean power
+// of the zones in it; if power is nil, all zones are weighted equally.
+func (s *EnergyIQService) ZoneAttribution(ctx context.Context, homeID HomeID, month time.Time, power map[ZoneID]float64) ([]ZoneAttribution, error) {
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	to := from.AddDate(0, 1, -1)
+	if now := time.Now(); to.After(now) {
+		to = now
+	}
+
+	runningTimes, err := s.GetRunningTimes(ctx, homeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := (*ZoneService)(s).List(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultPower := 1.0
+	if len(power) > 0 {
+		var sum float64
+		for _, p := range power {
+			sum += p
+		}
+		defaultPower = sum / float64(len(power))
+	}
+
+	zoneSeconds := map[ZoneID]int{}
+	for _, day := range runningTimes.RunningTimes {
+		for _, zone := range day.Zones {
+			zoneSeconds[zone.ID] += zone.RunningTimeInSeconds
+		}
+	}
+
+	var attributions []ZoneAttribution
+	var total float64
+	for _, zone := range zones {
+		if zone.Type != ZoneTypeHeating {
+			continue
+		}
+
+		p, ok := power[zone.ID]
+		if !ok {
+			p = defaultPower
+		}
+
+		demand := float64(zoneSeconds[zone.ID]) * p
+		total += demand
+		attributions = append(attributions, ZoneAttribution{ZoneID: zone.ID, ZoneName: zone.Name, Share: demand})
+	}
+
+	runningTime := time.Duration(runningTimes.Summary.TotalRunningTimeInSeconds) * time.Second
+	for i := range attributions {
+		if total > 0 {
+			attributions[i].Share /= total
+		}
+		attributions[i].RunningTime = time.Duration(attributions[i].Share * float64(runningTime))
+	}
+
+	return attributions, nil
+}
@@ -0,0 +1,204 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+)
+
+// TimetableType identifies the kind of timetable a zone follows.
+type TimetableType struct {
+	ID   int    `json:"id"`
+	Type string `json:"type,omitempty"`
+}
+
+// TimetableType values.
+var (
+	TimetableOneDay   = TimetableType{ID: 0, Type: "ONE_DAY"}
+	TimetableThreeDay = TimetableType{ID: 1, Type: "THREE_DAY"}
+	TimetableSevenDay = TimetableType{ID: 2, Type: "SEVEN_DAY"}
+)
+
+// DayType identifies the days a block of a timetable applies to.
+type DayType string
+
+const (
+	DayTypeMondayToSunday DayType = "MONDAY_TO_SUNDAY"
+	DayTypeMondayToFriday DayType = "MONDAY_TO_FRIDAY"
+	DayTypeMonday         DayType = "MONDAY"
+	DayTypeTuesday        DayType = "TUESDAY"
+	DayTypeWednesday      DayType = "WEDNESDAY"
+	DayTypeThursday       DayType = "THURSDAY"
+	DayTypeFriday         DayType = "FRIDAY"
+	DayTypeSaturday       DayType = "SATURDAY"
+	DayTypeSunday         DayType = "SUNDAY"
+)
+
+// DayTypes returns the day types used by the timetable type, or nil if the
+// type is unknown.
+func (t TimetableType) DayTypes() []DayType {
+	switch t.ID {
+	case TimetableOneDay.ID:
+		return []DayType{DayTypeMondayToSunday}
+	case TimetableThreeDay.ID:
+		return []DayType{DayTypeMondayToFriday, DayTypeSaturday, DayTypeSunday}
+	case TimetableSevenDay.ID:
+		return []DayType{
+			DayTypeMonday, DayTypeTuesday, DayTypeWednesday, DayTypeThursday,
+			DayTypeFriday, DayTypeSaturday, DayTypeSunday,
+		}
+	default:
+		return nil
+	}
+}
+
+// ScheduleBlock is a block of a timetable. Start and End are local times of
+// day formatted as "15:04"; an End of "00:00" denotes the end of the day.
+// Blocks with GeolocationOverride set ignore the presence of the home.
+type ScheduleBlock struct {
+	DayType             DayType     `json:"dayType"`
+	Start               string      `json:"start"`
+	End                 string      `json:"end"`
+	GeolocationOverride bool        `json:"geolocationOverride"`
+	Setting             ZoneSetting `json:"setting"`
+}
+
+// AwayConfiguration represents the setting of a zone while everybody is away.
+type AwayConfiguration struct {
+	Type            ZoneType     `json:"type"`
+	AutoAdjust      bool         `json:"autoAdjust"`
+	ComfortLevel    int          `json:"comfortLevel,omitempty"`
+	PreheatingLevel string       `json:"preheatingLevel,omitempty"`
+	Setting         *ZoneSetting `json:"setting,omitempty"`
+}
+
+// GetActiveTimetable returns the type of the timetable the zone with the given
+// ID of the provided home ID follows.
+func (s *ZoneService) GetActiveTimetable(ctx context.Context, homeID HomeID, zoneID ZoneID) (*TimetableType, error) {
+	req, err := s.client.newRequest("ZoneService", "GetActiveTimetable", "GET", fmt.Sprintf("homes/%d/zones/%d/schedule/activeTimetable", homeID, zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var timetable *TimetableType
+	_, err = s.client.Do(ctx, req, &timetable)
+	if err != nil {
+		return nil, err
+	}
+
+	return timetable, nil
+}
+
+// SetActiveTimetable sets the type of the timetable the zone with the given ID
+// of the provided home ID follows.
+func (s *ZoneService) SetActiveTimetable(ctx context.Context, homeID HomeID, zoneID ZoneID, timetable TimetableType, opts ...WriteOption) (*TimetableType, error) {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "SetActiveTimetable", "PUT", fmt.Sprintf("homes/%d/zones/%d/schedule/activeTimetable", homeID, zoneID), &TimetableType{ID: timetable.ID}, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var active *TimetableType
+	_, err = s.client.Do(ctx, req, &active)
+	if err != nil {
+		return nil, err
+	}
+
+	return active, nil
+}
+
+// GetScheduleBlocks returns the blocks of the timetable with the given ID of
+// the zone with the given ID of the provided home ID.
+func (s *ZoneService) GetScheduleBlocks(ctx context.Context, homeID HomeID, zoneID ZoneID, timetableID int) ([]ScheduleBlock, error) {
+	req, err := s.client.newRequest("ZoneService", "GetScheduleBlocks", "GET", fmt.Sprintf("homes/%d/zones/%d/schedule/timetables/%d/blocks", homeID, zoneID, timetableID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []ScheduleBlock
+	_, err = s.client.Do(ctx, req, &blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+// SetScheduleBlocks replaces the blocks of the given day type of the timetable
+// with the given ID of the zone with the given ID of the provided home ID. The
+// blocks must cover the whole day.
+func (s *ZoneService) SetScheduleBlocks(ctx context.Context, homeID HomeID, zoneID ZoneID, timetableID int, dayType DayType, blocks []ScheduleBlock, opts ...WriteOption) ([]ScheduleBlock, error) {
+	o := newWriteOptions(opts)
+
+	path := fmt.Sprintf("homes/%d/zones/%d/schedule/timetables/%d/blocks/%s", homeID, zoneID, timetableID, dayType)
+	req, err := s.client.newRequest("ZoneService", "SetScheduleBlocks", "PUT", path, blocks, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []ScheduleBlock
+	_, err = s.client.Do(ctx, req, &applied)
+	if err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// GetAwayConfiguration returns the away configuration of the zone with the
+// given ID of the provided home ID.
+func (s *ZoneService) GetAwayConfiguration(ctx context.Context, homeID HomeID, zoneID ZoneID) (*AwayConfiguration, error) {
+	req, err := s.client.newRequest("ZoneService", "GetAwayConfiguration", "GET", fmt.Sprintf("homes/%d/zones/%d/schedule/awayConfiguration", homeID, zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var away *AwayConfiguration
+	_, err = s.client.Do(ctx, req, &away)
+	if err != nil {
+		return nil, err
+	}
+
+	return away, nil
+}
+
+// SetAwayConfiguration replaces the away configuration of the zone with the
+// given ID of the provided home ID.
+func (s *ZoneService) SetAwayConfiguration(ctx context.Context, homeID HomeID, zoneID ZoneID, away AwayConfiguration, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "SetAwayConfiguration", "PUT", fmt.Sprintf("homes/%d/zones/%d/schedule/awayConfiguration", homeID, zoneID), away, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	return err
+}
+
+// SetAwayConfigurationWithChanges replaces the away configuration of the zone
+// with the given ID of the provided home ID, like SetAwayConfiguration, and
+// returns the resulting away configuration together with the fields that were
+// changed by the update.
+func (s *ZoneService) SetAwayConfigurationWithChanges(ctx context.Context, homeID HomeID, zoneID ZoneID, away AwayConfiguration, opts ...WriteOption) (*AwayConfiguration, []FieldChange, error) {
+	before, err := s.GetAwayConfiguration(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.SetAwayConfiguration(ctx, homeID, zoneID, away, opts...); err != nil {
+		return nil, nil, err
+	}
+
+	after, err := s.GetAwayConfiguration(ctx, homeID, zoneID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := Diff(before, after)
+	if err != nil {
+		return after, nil, err
+	}
+
+	return after, changes, nil
+}
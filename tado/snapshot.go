@@ -0,0 +1,107 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HomeSnapshot is a point-in-time view of a home, combining the home details,
+// its presence state, the outside weather and the state of its rooms. The
+// temperatures of the rooms are in Unit, the preferred unit of the client.
+type HomeSnapshot struct {
+	Time    time.Time       `json:"time"`
+	Unit    TemperatureUnit `json:"unit"`
+	Home    *Home           `json:"home"`
+	State   *State          `json:"state"`
+	Weather *Weather        `json:"weather"`
+	Rooms   []RoomSnapshot  `json:"rooms"`
+}
+
+// RoomSnapshot is a point-in-time view of a single room (zone) of a home.
+// Target is nil when the room is switched off.
+type RoomSnapshot struct {
+	ZoneID      ZoneID   `json:"zoneId"`
+	Name        string   `json:"name"`
+	Temperature float64  `json:"temperature"`
+	Target      *float64 `json:"target"`
+	Humidity    float64  `json:"humidity"`
+}
+
+// Snapshot returns a HomeSnapshot of the home with the given ID.
+//
+// If the state of some rooms cannot be retrieved, the snapshot of the other
+// rooms is returned together with a *MultiError.
+func (s *HomeService) Snapshot(ctx context.Context, id HomeID) (*HomeSnapshot, error) {
+	home, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.GetState(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	weather, err := s.GetWeather(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	zones, err := (*ZoneService)(s).List(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	states, err := (*ZoneService)(s).States(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	unit := s.client.unitOf(home)
+	rooms := []RoomSnapshot{}
+	errs := &MultiError{}
+	for _, zone := range zones {
+		if zone.Type != ZoneTypeHeating {
+			continue
+		}
+
+		zoneState, ok := states[zone.ID]
+		if !ok {
+			errs.Add(fmt.Sprintf("zone %d", zone.ID), errNoZoneState)
+			continue
+		}
+
+		rooms = append(rooms, newRoomSnapshot(zone, zoneState, unit))
+	}
+
+	return &HomeSnapshot{
+		Time:    time.Now(),
+		Unit:    unit,
+		Home:    home,
+		State:   state,
+		Weather: weather,
+		Rooms:   rooms,
+	}, errs.ErrorOrNil()
+}
+
+// newRoomSnapshot returns a RoomSnapshot of the given zone and its state, with
+// temperatures in the given unit.
+func newRoomSnapshot(zone Zone, state *ZoneState, unit TemperatureUnit) RoomSnapshot {
+	room := RoomSnapshot{ZoneID: zone.ID, Name: zone.Name}
+
+	if t := state.SensorDataPoints.InsideTemperature; t != nil {
+		room.Temperature = t.In(unit)
+	}
+
+	if h := state.SensorDataPoints.Humidity; h != nil {
+		room.Humidity = h.Percentage
+	}
+
+	if state.Setting.Power == PowerOn && state.Setting.Temperature != nil {
+		target := state.Setting.Temperature.In(unit)
+		room.Target = &target
+	}
+
+	return room
+}
@@ -7,10 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/oauth2"
 )
@@ -26,15 +30,51 @@ var ErrNonNilContext = errors.New("context must not be nil")
 // Client is the main client for interacting with the Tado API.
 // The Client is safe for concurrent use by multiple goroutines.
 type Client struct {
-	authenticator Authenticator
-	client        *http.Client
-	baseURL       *url.URL
-	userAgent     string
-	common        service
+	authenticator      Authenticator
+	authHeaderProvider AuthHeaderProvider
+	auth               *lazyTokenTransport
+	client             *http.Client
+	plainClient        *http.Client
+	unauthenticated    bool
+	middleware         []TransportMiddleware
+	baseURL            *url.URL
+	hopsURL            *url.URL
+	minderURL          *url.URL
+	energyIQURL        *url.URL
+	energySavingsURL   *url.URL
+	acmeURL            *url.URL
+	userAgent          string
+	unit               TemperatureUnit
+	common             service
+	err                error
+
+	mu            sync.Mutex
+	subscriptions map[string]int
+	cache         Cache
+	codec         Codec
+	responseCache *responseCache
+	conditional   bool
+	userID        string
+
+	timeouts    *TimeoutProfile
+	retry       *retryConfig
+	limiter     tokenLimiter
+	scheduler   *scheduler
+	concurrency *concurrencyLimiter
+
+	requestHooks  []RequestHook
+	responseHooks []ResponseHook
+	logger        *slog.Logger
+	logBodies     bool
 
 	User         *UserService
 	Home         *HomeService
 	MobileDevice *MobileDeviceService
+	Zone         *ZoneService
+	Device       *DeviceService
+	EnergyIQ     *EnergyIQService
+	Report       *ReportService
+	Room         *RoomService
 }
 
 // BaseURL returns a copy of the base URL configuration
@@ -55,6 +95,94 @@ func WithAuthenticator(auth Authenticator) ClientOption {
 	}
 }
 
+// WithAuthHeaderProvider sets a provider for the Authorization header of every
+// request. When set, no Authenticator is used and the client never holds a
+// Tado token itself, which allows requests to flow through a gateway that
+// injects the credentials.
+func WithAuthHeaderProvider(provider AuthHeaderProvider) ClientOption {
+	return func(c *Client) {
+		c.authHeaderProvider = provider
+	}
+}
+
+// WithHTTPClient sets the http.Client used to send requests, e.g. to use a
+// proxy, add instrumentation or use a test transport. Authentication is
+// layered on top of its Transport, and it is also used to acquire and refresh
+// tokens. By default, a zero http.Client is used.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.plainClient = httpClient
+	}
+}
+
+// TransportMiddleware wraps the RoundTripper that sends the API requests of a
+// client, e.g. to trace them. The Operation of a request can be obtained from
+// its context using OperationFromContext.
+type TransportMiddleware func(http.RoundTripper) http.RoundTripper
+
+// WithTransportMiddleware adds a middleware that wraps the transport of the
+// API requests, on top of authentication, so that it sees the requests as
+// sent, without the requests acquiring and refreshing tokens. Middleware is
+// applied in the order it was added, the first being the outermost.
+func WithTransportMiddleware(middleware TransportMiddleware) ClientOption {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware)
+	}
+}
+
+// WithBaseURL sets the base URL of the Tado API, e.g. to point the client at a
+// mock server. A trailing slash is added if missing. By default,
+// DefaultBaseURL is used.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			c.err = fmt.Errorf("invalid base URL %q: %w", baseURL, err)
+			return
+		}
+
+		c.baseURL = u
+	}
+}
+
+// parseServiceURL parses the base URL of the API of the given service, adding
+// a trailing slash if missing. If it is invalid, the error is recorded in c.err
+// and nil is returned.
+func (c *Client) parseServiceURL(service, serviceURL string) *url.URL {
+	if !strings.HasSuffix(serviceURL, "/") {
+		serviceURL += "/"
+	}
+
+	u, err := url.Parse(serviceURL)
+	if err != nil {
+		c.err = fmt.Errorf("invalid %s URL %q: %w", service, serviceURL, err)
+		return nil
+	}
+
+	return u
+}
+
+// WithUserAgent sets the User-Agent header sent with every request. By
+// default, DefaultUserAgent is used.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithUnauthenticated configures the client to send all requests using a plain
+// http.Client without any authentication, e.g. when talking to a fake server.
+// No Authenticator is used.
+func WithUnauthenticated() ClientOption {
+	return func(c *Client) {
+		c.unauthenticated = true
+	}
+}
+
 // NewClient returns a new thread-safe Client instance with the given options.
 // The returned Client can be used concurrently from multiple goroutines.
 //
@@ -78,48 +206,156 @@ func WithAuthenticator(auth Authenticator) ClientOption {
 //
 //	auth := tado.NewDeviceAuthenticator(config)
 //	client := tado.NewClient(tado.WithAuthenticator(auth))
+//
+// The token is only acquired when the first request is sent, so NewClient
+// does not block on authentication. NewClient panics if the options are
+// invalid; use NewClientWithContext to handle such errors.
 func NewClient(opts ...ClientOption) *Client {
+	tc, err := NewClientWithContext(context.Background(), opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return tc
+}
+
+// NewClientWithContext returns a new thread-safe Client instance with the
+// given options, like NewClient, but returns an error instead of panicking if
+// the options are invalid.
+//
+// The Authenticator is not called until the first request is sent. ctx is
+// used to acquire and refresh the token, and must therefore outlive the
+// client. If acquiring the token fails, the error is returned by the request
+// and acquisition is attempted again on the next request.
+func NewClientWithContext(ctx context.Context, opts ...ClientOption) (*Client, error) {
 	tc := &Client{}
 	for _, opt := range opts {
 		opt(tc)
 	}
 
-	if tc.authenticator == nil {
+	if tc.authenticator == nil && tc.authHeaderProvider == nil && !tc.unauthenticated {
 		tc.authenticator = NewDeviceAuthenticator(nil)
 	}
 
-	tc.initialize()
-	return tc
+	if err := tc.initialize(ctx); err != nil {
+		return nil, err
+	}
+
+	return tc, nil
 }
 
 // initialize sets up the client with default values and initializes the
 // services.
-func (c *Client) initialize() {
+func (c *Client) initialize(ctx context.Context) error {
+	err := c.err
+	if err != nil {
+		return err
+	}
+
 	var once sync.Once
 	once.Do(func() {
+		if c.plainClient == nil {
+			c.plainClient = &http.Client{}
+		}
+
+		if c.client == nil && c.unauthenticated {
+			c.client = c.plainClient
+		}
+
+		if c.client == nil && c.authHeaderProvider != nil {
+			c.client = withTransport(c.plainClient, &authHeaderTransport{
+				provider: c.authHeaderProvider,
+				base:     c.plainClient.Transport,
+			})
+		}
+
 		if c.client == nil {
-			token, err := c.authenticator.TokenSource(context.Background())
-			if err != nil {
-				panic(err)
+			c.auth = &lazyTokenTransport{
+				ctx:           context.WithValue(ctx, oauth2.HTTPClient, c.plainClient),
+				authenticator: c.authenticator,
+				base:          c.plainClient.Transport,
 			}
+			c.client = withTransport(c.plainClient, c.auth)
+		}
 
-			c.client = oauth2.NewClient(context.Background(), token)
+		if len(c.middleware) > 0 {
+			transport := c.client.Transport
+			if transport == nil {
+				transport = http.DefaultTransport
+			}
+			for _, middleware := range slices.Backward(c.middleware) {
+				transport = middleware(transport)
+			}
+			c.client = withTransport(c.client, transport)
 		}
 
 		if c.baseURL == nil {
 			c.baseURL, _ = url.Parse(DefaultBaseURL)
 		}
 
+		if !strings.HasSuffix(c.baseURL.Path, "/") {
+			err = fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.baseURL)
+			return
+		}
+
+		if c.hopsURL == nil {
+			c.hopsURL, _ = url.Parse(DefaultHopsURL)
+		}
+
+		if c.minderURL == nil {
+			c.minderURL, _ = url.Parse(DefaultMinderURL)
+		}
+
+		if c.energyIQURL == nil {
+			c.energyIQURL, _ = url.Parse(DefaultEnergyIQURL)
+		}
+
+		if c.energySavingsURL == nil {
+			c.energySavingsURL, _ = url.Parse(DefaultEnergySavingsURL)
+		}
+
+		if c.acmeURL == nil {
+			c.acmeURL, _ = url.Parse(DefaultAcmeURL)
+		}
+
 		if c.userAgent == "" {
 			c.userAgent = DefaultUserAgent
 		}
 
+		if c.cache == nil {
+			c.cache = NewMemoryCache()
+		}
+
+		if c.codec == nil {
+			c.codec = JSONCodec{}
+		}
+
+		if c.timeouts == nil {
+			profile := DefaultTimeoutProfile
+			c.timeouts = &profile
+		}
+
 		c.common.client = c
 
 		c.User = (*UserService)(&c.common)
 		c.Home = (*HomeService)(&c.common)
 		c.MobileDevice = (*MobileDeviceService)(&c.common)
+		c.Zone = (*ZoneService)(&c.common)
+		c.Device = (*DeviceService)(&c.common)
+		c.EnergyIQ = (*EnergyIQService)(&c.common)
+		c.Report = (*ReportService)(&c.common)
+		c.Room = (*RoomService)(&c.common)
 	})
+
+	return err
+}
+
+// withTransport returns a copy of the given http.Client using the given
+// Transport.
+func withTransport(hc *http.Client, transport http.RoundTripper) *http.Client {
+	clone := *hc
+	clone.Transport = transport
+	return &clone
 }
 
 // clone returns a copy of the client. Must be initialized before use using
@@ -144,6 +380,46 @@ func (c *Client) initialize() {
 
 type RequestOption func(req *http.Request)
 
+// apiVersionPattern matches the API version segment of a request path.
+var apiVersionPattern = regexp.MustCompile(`/api/v\d+/`)
+
+// WithAPIVersion returns a RequestOption that sends the request to the given
+// version of the Tado API, e.g. WithAPIVersion(1) for endpoints that still
+// live under /api/v1, regardless of the version of the base URL.
+func WithAPIVersion(version int) RequestOption {
+	return func(req *http.Request) {
+		replaced := false
+		req.URL.Path = apiVersionPattern.ReplaceAllStringFunc(req.URL.Path, func(s string) string {
+			if replaced {
+				return s
+			}
+			replaced = true
+			return fmt.Sprintf("/api/v%d/", version)
+		})
+		req.URL.RawPath = ""
+	}
+}
+
+// withoutAuthKey is the context key used to mark requests that must be sent
+// without authentication.
+type withoutAuthKey struct{}
+
+// WithoutAuth returns a RequestOption that sends the request using a plain
+// http.Client, without authentication. It is meant for public endpoints that
+// do not require a token.
+func WithoutAuth() RequestOption {
+	return func(req *http.Request) {
+		*req = *req.WithContext(context.WithValue(req.Context(), withoutAuthKey{}, true))
+	}
+}
+
+// writable is implemented by models whose representation differs when they
+// are written to the API, typically to strip read-only fields such as IDs and
+// timestamps that some endpoints reject.
+type writable interface {
+	writeBody() any
+}
+
 func (c *Client) NewRequest(method, path string, body any, opts ...RequestOption) (*http.Request, error) {
 	if !strings.HasSuffix(c.baseURL.Path, "/") {
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL())
@@ -154,6 +430,10 @@ func (c *Client) NewRequest(method, path string, body any, opts ...RequestOption
 		return nil, err
 	}
 
+	if w, ok := body.(writable); ok {
+		body = w.writeBody()
+	}
+
 	var buf io.ReadWriter
 	if body != nil {
 		buf = &bytes.Buffer{}
@@ -165,7 +445,8 @@ func (c *Client) NewRequest(method, path string, body any, opts ...RequestOption
 		}
 	}
 
-	req, err := http.NewRequest(method, url.String(), buf)
+	ctx := context.WithValue(context.Background(), operationKey{}, newOperation(url))
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +479,8 @@ func newResponse(r *http.Response) *Response {
 }
 
 // bareDo sends an API request using the provided http.Client (`caller`) and
-// lets you handle the http.Response on your own.
+// lets you handle the http.Response on your own. The request and response
+// hooks of the client are called around the request.
 //
 // The provided ctx must not be nil. If it is, bareDo returns ErrNonNilContext.
 func (c *Client) bareDo(ctx context.Context, caller *http.Client, req *http.Request) (*Response, error) {
@@ -208,11 +490,15 @@ func (c *Client) bareDo(ctx context.Context, caller *http.Client, req *http.Requ
 
 	req = req.WithContext(ctx)
 
+	c.beforeRequest(req)
+	start := time.Now()
 	res, err := caller.Do(req)
 	var response *Response
 	if res != nil {
 		response = newResponse(res)
 	}
+	c.afterResponse(response, err, start)
+	c.logResponse(ctx, req, response, err, time.Since(start))
 
 	if err != nil {
 		select {
@@ -235,11 +521,35 @@ func (c *Client) bareDo(ctx context.Context, caller *http.Client, req *http.Requ
 }
 
 // BareDo sends an API request and lets you handle the http.Response on your
-// own.
+// own. If ctx has no deadline, the default timeout of the endpoint class of the
+// request applies until the response body is closed, see TimeoutProfile.
 //
 // The provided ctx must not be nil. If it is, BareDo returns ErrNonNilContext.
 func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, error) {
-	return c.bareDo(ctx, c.client, req)
+	if ctx == nil {
+		return nil, ErrNonNilContext
+	}
+
+	ctx = withOperation(ctx, req)
+
+	if err := c.waitRateLimit(ctx, req); err != nil {
+		return nil, err
+	}
+
+	caller := c.client
+	if withoutAuth, _ := req.Context().Value(withoutAuthKey{}).(bool); withoutAuth {
+		caller = c.plainClient
+	}
+
+	ctx, cancel := c.withAttemptTimeout(ctx, req)
+	res, err := c.bareDo(ctx, caller, req)
+	if res == nil {
+		cancel()
+		return res, err
+	}
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+
+	return res, err
 }
 
 // Do sends an API request and returns the API response. The API response is
@@ -248,15 +558,50 @@ func (c *Client) BareDo(ctx context.Context, req *http.Request) (*Response, erro
 // raw response body will be written to v, without attempting to decode it. If v
 // is nil and no error occurs, the response is returned as is.
 //
+// If ctx has no deadline, the default timeout of the endpoint class of the
+// request is applied to every attempt, see TimeoutProfile. If the client
+// limits the number of concurrent requests, Do waits for a slot first, see
+// WithMaxConcurrentRequests.
+//
+// If the client caches responses, see WithCache, the responses of cached
+// resources are served from the cache. If it sends conditional requests, see
+// WithConditionalRequests, unchanged GET responses are served from the cache.
+//
+// A 404 Not Found of an endpoint that does not exist for the generation of the
+// home is returned as a WrongGenerationError.
+//
 // The provided ctx must not be nil. If it is, Do returns ErrNonNilContext.
 func (c *Client) Do(ctx context.Context, req *http.Request, v any) (*Response, error) {
 	if ctx == nil {
-		var cancel context.CancelFunc
-		ctx, cancel = context.WithTimeout(context.Background(), DefaultTimeout)
-		defer cancel()
+		ctx = context.Background()
+	}
+
+	var res *Response
+	var err error
+	if ttl, homeID := c.cacheTTL(req, v); ttl > 0 {
+		res, err = c.doCached(ctx, req, v, ttl, homeID)
+	} else {
+		res, err = c.do(ctx, req, v)
+	}
+	if err != nil {
+		return res, c.checkGeneration(ctx, req, err)
+	}
+	c.invalidateAfterWrite(ctx, req)
+
+	return res, nil
+}
+
+// do sends the request and decodes the response, see Do.
+func (c *Client) do(ctx context.Context, req *http.Request, v any) (*Response, error) {
+	if c.concurrency != nil {
+		release, err := c.concurrency.acquire(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
 	}
 
-	res, err := c.BareDo(ctx, req)
+	res, err := c.sendConditional(ctx, req)
 	if err != nil {
 		return res, err
 	}
@@ -0,0 +1,38 @@
+package tado
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// roundTripperFunc creates a RoundTripper (transport).
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+// newTestClient returns an unauthenticated client that sends its requests
+// using transport instead of the network.
+func newTestClient(transport roundTripperFunc, opts ...ClientOption) *Client {
+	opts = append([]ClientOption{
+		WithUnauthenticated(),
+		func(c *Client) {
+			c.plainClient = &http.Client{Transport: transport}
+		},
+	}, opts...)
+
+	return NewClient(opts...)
+}
+
+// newTestResponse returns a response to req with the given status and body.
+func newTestResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
@@ -0,0 +1,79 @@
+package tado
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Temperature represents a temperature in both Celsius and Fahrenheit, in the
+// shape used by the Tado API: {"celsius": 21.5, "fahrenheit": 70.7}. Use
+// Celsius, Fahrenheit or NewTemperature to construct one, so that both values
+// are set.
+type Temperature struct {
+	Celsius    float64 `json:"celsius"`
+	Fahrenheit float64 `json:"fahrenheit"`
+}
+
+// Celsius returns the Temperature of the given value in degrees Celsius.
+func Celsius(value float64) Temperature {
+	return Temperature{Celsius: value, Fahrenheit: value*9/5 + 32}
+}
+
+// Fahrenheit returns the Temperature of the given value in degrees
+// Fahrenheit.
+func Fahrenheit(value float64) Temperature {
+	return Temperature{Celsius: (value - 32) * 5 / 9, Fahrenheit: value}
+}
+
+// NewTemperature returns the Temperature of the given value in the given
+// unit. Values in any unit other than UnitFahrenheit are taken as Celsius.
+func NewTemperature(value float64, unit TemperatureUnit) Temperature {
+	if unit == UnitFahrenheit {
+		return Fahrenheit(value)
+	}
+
+	return Celsius(value)
+}
+
+// In returns the value of the temperature in the given unit. Any unit other
+// than UnitFahrenheit is taken as Celsius.
+func (t Temperature) In(unit TemperatureUnit) float64 {
+	if unit == UnitFahrenheit {
+		return t.Fahrenheit
+	}
+
+	return t.Celsius
+}
+
+// Add returns the temperature raised by the given difference in degrees
+// Celsius, e.g. to apply a TemperatureOffset.
+func (t Temperature) Add(celsius float64) Temperature {
+	return Celsius(t.Celsius + celsius)
+}
+
+// Format returns the temperature in the given unit with one decimal and the
+// symbol of the unit, e.g. "21.5°C".
+func (t Temperature) Format(unit TemperatureUnit) string {
+	return fmt.Sprintf("%.1f%s", t.In(unit), unit.Symbol())
+}
+
+// String returns the temperature in degrees Celsius with one decimal, e.g.
+// "21.5°C". It implements the fmt.Stringer interface; use Format for other
+// units.
+func (t Temperature) String() string {
+	return t.Format(UnitCelsius)
+}
+
+// MarshalText returns the temperature in degrees Celsius, like String. It
+// implements the encoding.TextMarshaler interface.
+func (t Temperature) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. It keeps the object
+// representation of the API, which would otherwise be replaced by the text
+// of MarshalText.
+func (t Temperature) MarshalJSON() ([]byte, error) {
+	type temperature Temperature
+	return json.Marshal(temperature(t))
+}
@@ -0,0 +1,69 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TemperatureOffset is the calibration offset applied to the temperature
+// measured by a device. Unlike a Temperature, the Fahrenheit value is a
+// difference and has no +32 term.
+type TemperatureOffset struct {
+	Celsius    float64 `json:"celsius"`
+	Fahrenheit float64 `json:"fahrenheit"`
+}
+
+// NewTemperatureOffset returns the TemperatureOffset of the given offset in
+// degrees Celsius.
+func NewTemperatureOffset(celsius float64) TemperatureOffset {
+	return TemperatureOffset{Celsius: celsius, Fahrenheit: celsius * 9 / 5}
+}
+
+// In returns the value of the offset in the given unit. Any unit other than
+// UnitFahrenheit is taken as Celsius.
+func (o TemperatureOffset) In(unit TemperatureUnit) float64 {
+	if unit == UnitFahrenheit {
+		return o.Fahrenheit
+	}
+
+	return o.Celsius
+}
+
+// GetTemperatureOffset returns the temperature offset of the device with the
+// given serial number.
+func (s *DeviceService) GetTemperatureOffset(ctx context.Context, serialNo DeviceSerial) (*TemperatureOffset, error) {
+	req, err := s.client.newRequest("DeviceService", "GetTemperatureOffset", "GET", fmt.Sprintf("devices/%s/temperatureOffset", url.PathEscape(string(serialNo))), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var offset *TemperatureOffset
+	_, err = s.client.Do(ctx, req, &offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return offset, nil
+}
+
+// SetTemperatureOffset sets the temperature offset of the device with the
+// given serial number to the given offset in degrees Celsius, e.g. to
+// calibrate a radiator valve that measures too high a temperature.
+func (s *DeviceService) SetTemperatureOffset(ctx context.Context, serialNo DeviceSerial, celsius float64, opts ...WriteOption) (*TemperatureOffset, error) {
+	o := newWriteOptions(opts)
+
+	offset := NewTemperatureOffset(celsius)
+	req, err := s.client.newRequest("DeviceService", "SetTemperatureOffset", "PUT", fmt.Sprintf("devices/%s/temperatureOffset", url.PathEscape(string(serialNo))), &offset, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var applied *TemperatureOffset
+	_, err = s.client.Do(ctx, req, &applied)
+	if err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
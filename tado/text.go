@@ -0,0 +1,134 @@
+package tado
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// String implements the fmt.Stringer interface.
+func (p Presence) String() string {
+	return string(p)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p Presence) MarshalText() ([]byte, error) {
+	return []byte(p), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The text is
+// case-insensitive, so that e.g. "away" can be used as a flag value, and
+// unknown values are rejected.
+func (p *Presence) UnmarshalText(text []byte) error {
+	v, err := parseEnum("presence", text, PresenceHome, PresenceAway)
+	if err != nil {
+		return err
+	}
+
+	*p = v
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Unlike
+// UnmarshalText, it accepts unknown values as is, so that values added to the
+// API do not break decoding its responses.
+func (p *Presence) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(p))
+}
+
+// String implements the fmt.Stringer interface.
+func (t ZoneType) String() string {
+	return string(t)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t ZoneType) MarshalText() ([]byte, error) {
+	return []byte(t), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The text is
+// case-insensitive, so that e.g. "hot_water" can be used as a flag value, and
+// unknown values are rejected.
+func (t *ZoneType) UnmarshalText(text []byte) error {
+	v, err := parseEnum("zone type", text, ZoneTypeHeating, ZoneTypeHotWater, ZoneTypeAirConditioning)
+	if err != nil {
+		return err
+	}
+
+	*t = v
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Unlike
+// UnmarshalText, it accepts unknown values as is, so that values added to the
+// API do not break decoding its responses.
+func (t *ZoneType) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(t))
+}
+
+// String implements the fmt.Stringer interface.
+func (s MountingState) String() string {
+	return string(s)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s MountingState) MarshalText() ([]byte, error) {
+	return []byte(s), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. The text is
+// case-insensitive, and unknown values are rejected.
+func (s *MountingState) UnmarshalText(text []byte) error {
+	v, err := parseEnum("mounting state", text, MountingStateCalibrated, MountingStateMounted, MountingStateUnmounted, MountingStateMountingMode, MountingStateNeedsCalibration, MountingStateCalibrationError)
+	if err != nil {
+		return err
+	}
+
+	*s = v
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Unlike
+// UnmarshalText, it accepts unknown values as is, so that values added to the
+// API do not break decoding its responses.
+func (s *MountingState) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, (*string)(s))
+}
+
+// String returns "ONLINE" or "OFFLINE". It implements the fmt.Stringer
+// interface.
+func (s ConnectionState) String() string {
+	if s.Value {
+		return "ONLINE"
+	}
+
+	return "OFFLINE"
+}
+
+// MarshalText returns "ONLINE" or "OFFLINE". It implements the
+// encoding.TextMarshaler interface.
+func (s ConnectionState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. It keeps the object
+// representation of the API, which would otherwise be replaced by the text
+// of MarshalText.
+func (s ConnectionState) MarshalJSON() ([]byte, error) {
+	type connectionState ConnectionState
+	return json.Marshal(connectionState(s))
+}
+
+// parseEnum returns the value of known matching text case-insensitively, or
+// an error listing the known values of the enum with the given name.
+func parseEnum[T ~string](name string, text []byte, known ...T) (T, error) {
+	values := make([]string, 0, len(known))
+	for _, v := range known {
+		if strings.EqualFold(string(v), string(text)) {
+			return v, nil
+		}
+		values = append(values, string(v))
+	}
+
+	return "", fmt.Errorf("invalid %s %q, must be one of %s", name, text, strings.Join(values, ", "))
+}
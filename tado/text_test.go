@@ -0,0 +1,58 @@
+package tado
+
+import (
+	"encoding"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestMarshalText(t *testing.T) {
+	tests := []struct {
+		v    encoding.TextMarshaler
+		want string
+	}{
+		{PresenceAway, "AWAY"},
+		{ZoneTypeHotWater, "HOT_WATER"},
+		{ConnectionState{Value: true}, "ONLINE"},
+		{ConnectionState{}, "OFFLINE"},
+		{Celsius(21.5), "21.5°C"},
+		{Fahrenheit(70), "21.1°C"},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.v.MarshalText()
+		if err != nil {
+			t.Errorf("%#v: unexpected error: %v", tt.v, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%#v: got %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestMarshalJSON_keepsObjects(t *testing.T) {
+	timestamp := time.Date(2026, time.January, 15, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{ConnectionState{Value: true, Timestamp: timestamp}, `{"value":true,"timestamp":"2026-01-15T08:00:00Z"}`},
+		{Celsius(20), `{"celsius":20,"fahrenheit":68}`},
+		{
+			TemperatureDataPoint{Temperature: Celsius(20), Type: "TEMPERATURE", Timestamp: timestamp, Precision: Celsius(0.1)},
+			`{"celsius":20,"fahrenheit":68,"type":"TEMPERATURE","timestamp":"2026-01-15T08:00:00Z","precision":{"celsius":0.1,"fahrenheit":32.18}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.v)
+		if err != nil {
+			t.Errorf("%#v: unexpected error: %v", tt.v, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%#v: got %s, want %s", tt.v, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package tado
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// TimeoutProfile holds the default timeouts per endpoint class. They are
+// applied to every attempt of a request sent by the client when its context
+// has no deadline, so that waiting for the rate limit and the backoff between
+// retries do not count against them. A zero timeout disables the default
+// timeout for that class.
+type TimeoutProfile struct {
+	// State is the timeout of cheap state polls, such as zone and home states
+	// and the weather.
+	State time.Duration
+
+	// Default is the timeout of all other endpoints.
+	Default time.Duration
+
+	// Report is the timeout of expensive historic reports, such as day
+	// reports and energy consumption.
+	Report time.Duration
+}
+
+// DefaultTimeoutProfile is the TimeoutProfile used unless overridden using
+// WithTimeouts.
+var DefaultTimeoutProfile = TimeoutProfile{
+	State:   5 * time.Second,
+	Default: DefaultTimeout * time.Second,
+	Report:  60 * time.Second,
+}
+
+// WithTimeouts sets the default timeouts per endpoint class.
+func WithTimeouts(profile TimeoutProfile) ClientOption {
+	return func(c *Client) {
+		c.timeouts = &profile
+	}
+}
+
+// timeoutFor returns the default timeout of the given request.
+func (c *Client) timeoutFor(req *http.Request) time.Duration {
+	p, path := c.timeouts, req.URL.Path
+
+	switch {
+	case strings.Contains(path, "/dayReport"),
+		strings.Contains(path, "/consumption"),
+		strings.Contains(path, "/runningTimes"),
+		strings.HasPrefix(req.URL.String(), c.energySavingsURL.String()):
+		return p.Report
+	case strings.HasSuffix(path, "/state"),
+		strings.HasSuffix(path, "/zoneStates"),
+		strings.HasSuffix(path, "/weather"):
+		return p.State
+	default:
+		return p.Default
+	}
+}
+
+// withAttemptTimeout returns a copy of ctx with the default timeout of req, if
+// ctx has no deadline, and a function releasing it.
+func (c *Client) withAttemptTimeout(ctx context.Context, req *http.Request) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return ctx, func() {}
+	}
+
+	timeout := c.timeoutFor(req)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// cancelOnClose is a response body that releases the context of its request
+// when it is closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
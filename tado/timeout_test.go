@@ -0,0 +1,139 @@
+package tado
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_timeoutFor(t *testing.T) {
+	profile := TimeoutProfile{State: 1 * time.Second, Default: 2 * time.Second, Report: 3 * time.Second}
+	client := NewClient(WithUnauthenticated(), WithTimeouts(profile))
+
+	tests := []struct {
+		url  string
+		want time.Duration
+	}{
+		{"https://my.tado.com/api/v2/homes/1/zones/1/state", profile.State},
+		{"https://my.tado.com/api/v2/homes/1/zoneStates", profile.State},
+		{"https://my.tado.com/api/v2/homes/1/weather", profile.State},
+		{"https://my.tado.com/api/v2/homes/1/zones/1/dayReport?date=2026-01-15", profile.Report},
+		{"https://my.tado.com/api/v2/homes/1/consumption", profile.Report},
+		{"https://energy-bob.tado.com/1/2026-01", profile.Report},
+		{"https://my.tado.com/api/v2/me", profile.Default},
+		{"https://my.tado.com/api/v2/homes/1/zones/1/overlay", profile.Default},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest("GET", tt.url, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := client.timeoutFor(req); got != tt.want {
+			t.Errorf("timeoutFor(%s) = %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestClient_Do_timeout(t *testing.T) {
+	ownDeadline := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name         string
+		path         string
+		profile      TimeoutProfile
+		deadline     time.Time
+		wantTimeout  time.Duration
+		wantDeadline time.Time
+	}{
+		{
+			name:        "default timeout of the endpoint class",
+			path:        "homes/1/zones/1/state",
+			profile:     TimeoutProfile{State: time.Minute, Default: time.Hour},
+			wantTimeout: time.Minute,
+		},
+		{
+			name:         "deadline of the context",
+			path:         "homes/1/zones/1/state",
+			profile:      TimeoutProfile{State: time.Minute},
+			deadline:     ownDeadline,
+			wantDeadline: ownDeadline,
+		},
+		{
+			name:    "zero timeout",
+			path:    "me",
+			profile: TimeoutProfile{State: time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deadline time.Time
+			var hasDeadline bool
+			client := newTestClient(func(req *http.Request) (*http.Response, error) {
+				deadline, hasDeadline = req.Context().Deadline()
+				return newTestResponse(req, http.StatusOK, "{}"), nil
+			}, WithTimeouts(tt.profile))
+
+			ctx := context.Background()
+			if !tt.deadline.IsZero() {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithDeadline(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			req, err := client.NewRequest("GET", tt.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			if _, err := client.Do(ctx, req, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			end := time.Now()
+
+			switch {
+			case tt.wantTimeout > 0:
+				if !hasDeadline || deadline.Before(start.Add(tt.wantTimeout)) || deadline.After(end.Add(tt.wantTimeout)) {
+					t.Errorf("got deadline %v, want %v after the request was sent", deadline, tt.wantTimeout)
+				}
+			case !tt.wantDeadline.IsZero():
+				if !deadline.Equal(tt.wantDeadline) {
+					t.Errorf("got deadline %v, want %v", deadline, tt.wantDeadline)
+				}
+			case hasDeadline:
+				t.Errorf("got deadline %v, want none", deadline)
+			}
+		})
+	}
+}
+
+func TestClient_Do_timeoutPerAttempt(t *testing.T) {
+	var failed, deadline time.Time
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		if failed.IsZero() {
+			failed = time.Now()
+			return newTestResponse(req, http.StatusServiceUnavailable, ""), nil
+		}
+
+		deadline, _ = req.Context().Deadline()
+		return newTestResponse(req, http.StatusOK, "{}"), nil
+	}, WithTimeouts(TimeoutProfile{Default: time.Minute}), WithRetry(1, noBackoff))
+
+	req, err := client.NewRequest("GET", "me", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.Do(context.Background(), req, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the retry gets the full timeout, rather than what the first attempt left
+	if deadline.Before(failed.Add(time.Minute)) {
+		t.Errorf("got deadline %v for the retry, want at least a minute after the first attempt failed at %v", deadline, failed)
+	}
+}
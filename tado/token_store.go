@@ -0,0 +1,123 @@
+package tado
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenStore persists OAuth2 tokens between process runs.
+type TokenStore interface {
+	// Load returns the stored token, or nil if no token is stored.
+	Load() (*oauth2.Token, error)
+
+	// Save stores the given token, replacing any previously stored token.
+	Save(token *oauth2.Token) error
+}
+
+// FileTokenStore is a TokenStore that stores the token as JSON in a file,
+// readable only by the current user. The scopes granted to the token are
+// stored with it.
+type FileTokenStore struct {
+	path string
+}
+
+// NewFileTokenStore returns a FileTokenStore storing the token at path.
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+// Load implements the TokenStore interface.
+func (s *FileTokenStore) Load() (*oauth2.Token, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stored storedToken
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+
+	token := &stored.Token
+	if stored.Scope != "" {
+		token = token.WithExtra(map[string]any{"scope": stored.Scope})
+	}
+
+	return token, nil
+}
+
+// storedToken is the JSON representation of a token in a FileTokenStore.
+type storedToken struct {
+	oauth2.Token
+	Scope string `json:"scope,omitempty"`
+}
+
+// Save implements the TokenStore interface. The file is replaced atomically.
+func (s *FileTokenStore) Save(token *oauth2.Token) error {
+	scope, _ := token.Extra("scope").(string)
+	data, err := json.Marshal(&storedToken{Token: *token, Scope: scope})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), s.path)
+}
+
+// storingTokenSource is an oauth2.TokenSource that saves every new token
+// returned by its base TokenSource to a TokenStore.
+type storingTokenSource struct {
+	base  oauth2.TokenSource
+	store TokenStore
+
+	mu   sync.Mutex
+	last *oauth2.Token
+}
+
+// Token implements the oauth2.TokenSource interface.
+func (s *storingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.last == nil || s.last.AccessToken != token.AccessToken || s.last.RefreshToken != token.RefreshToken {
+		if err := s.store.Save(token); err != nil {
+			return nil, err
+		}
+		s.last = token
+	}
+
+	return token, nil
+}
@@ -0,0 +1,77 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TemperatureUnit is the unit in which temperatures are presented to a user.
+type TemperatureUnit string
+
+// TemperatureUnit constants.
+const (
+	UnitCelsius    TemperatureUnit = "CELSIUS"
+	UnitFahrenheit TemperatureUnit = "FAHRENHEIT"
+)
+
+// UnitTTL is the time the temperature unit of a home is cached for.
+var UnitTTL = 24 * time.Hour
+
+// Symbol returns the symbol of the unit, e.g. "°C".
+func (u TemperatureUnit) Symbol() string {
+	if u == UnitFahrenheit {
+		return "°F"
+	}
+
+	return "°C"
+}
+
+// WithPreferredUnit sets the unit in which helpers such as
+// ZoneService.SetTemperature and HomeService.Snapshot interpret and return
+// temperatures. By default, the TemperatureUnit of the home is used.
+func WithPreferredUnit(unit TemperatureUnit) ClientOption {
+	return func(c *Client) {
+		c.unit = unit
+	}
+}
+
+// PreferredUnit returns the unit in which helpers interpret and return
+// temperatures for the home with the given ID: the unit set with
+// WithPreferredUnit if any, or the TemperatureUnit of the home otherwise. The
+// unit of the home is cached for UnitTTL in the cache of the client.
+func (c *Client) PreferredUnit(ctx context.Context, homeID HomeID) (TemperatureUnit, error) {
+	if c.unit != "" {
+		return c.unit, nil
+	}
+
+	key := fmt.Sprintf("unit/%d", homeID)
+
+	var unit TemperatureUnit
+	if c.cacheGet(ctx, key, &unit) {
+		return unit, nil
+	}
+
+	home, err := c.Home.Get(ctx, homeID)
+	if err != nil {
+		return "", err
+	}
+
+	unit = c.unitOf(home)
+	c.cacheSet(ctx, key, unit, UnitTTL)
+
+	return unit, nil
+}
+
+// unitOf returns the preferred unit for the given home.
+func (c *Client) unitOf(home *Home) TemperatureUnit {
+	if c.unit != "" {
+		return c.unit
+	}
+
+	if home.TemperatureUnit == UnitFahrenheit {
+		return UnitFahrenheit
+	}
+
+	return UnitCelsius
+}
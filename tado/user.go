@@ -2,7 +2,9 @@ package tado
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sync"
 )
 
 // UserService handles communication with the user-related methods of the Tado
@@ -11,9 +13,9 @@ type UserService service
 
 // User represents a Tado user.
 type User struct {
-	Name          string         `json:"name,omitempty"`
-	Email         string         `json:"email,omitempty"`
-	Username      string         `json:"username,omitempty"`
+	Name          string         `json:"name,omitempty" redact:"true"`
+	Email         string         `json:"email,omitempty" redact:"true"`
+	Username      string         `json:"username,omitempty" redact:"true"`
 	ID            string         `json:"id,omitempty"`
 	Homes         []BareHome     `json:"homes,omitempty"`
 	Locale        string         `json:"locale,omitempty"`
@@ -21,13 +23,13 @@ type User struct {
 }
 
 type BareHome struct {
-	ID   int    `json:"id,omitempty"`
-	Name string `json:"name,omitempty"`
+	ID   HomeID `json:"id,omitempty"`
+	Name string `json:"name,omitempty" redact:"true"`
 }
 
 // Get returns the authenticated user.
 func (s *UserService) Get(ctx context.Context) (*User, error) {
-	req, err := s.client.NewRequest(http.MethodGet, "me", nil)
+	req, err := s.client.newRequest("UserService", "Get", http.MethodGet, "me", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -40,3 +42,60 @@ func (s *UserService) Get(ctx context.Context) (*User, error) {
 
 	return user, nil
 }
+
+// HomeDevice is a device together with the home and zone it belongs to.
+// ZoneID is zero for devices that do not belong to a zone, such as bridges.
+type HomeDevice struct {
+	HomeID   HomeID `json:"homeId"`
+	HomeName string `json:"homeName"`
+	ZoneID   ZoneID `json:"zoneId,omitempty"`
+	Device   Device `json:"device"`
+}
+
+// GetAllDevices returns the devices of all homes of the authenticated user.
+// The homes are queried concurrently. If some homes fail, the devices of the
+// other homes are returned together with a *MultiError.
+func (s *UserService) GetAllDevices(ctx context.Context) ([]HomeDevice, error) {
+	me, err := s.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]HomeDevice, len(me.Homes))
+	errs := make([]error, len(me.Homes))
+
+	var wg sync.WaitGroup
+	for i, home := range me.Homes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			entries, err := (*DeviceService)(s).GetDeviceList(ctx, home.ID)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			for _, entry := range entries {
+				device := HomeDevice{HomeID: home.ID, HomeName: home.Name, Device: entry.Device}
+				if entry.Zone != nil {
+					device.ZoneID = entry.Zone.Discriminator
+				}
+				results[i] = append(results[i], device)
+			}
+		}()
+	}
+	wg.Wait()
+
+	devices := []HomeDevice{}
+	multiErr := &MultiError{}
+	for i, home := range me.Homes {
+		if errs[i] != nil {
+			multiErr.Add(fmt.Sprintf("home %d", home.ID), errs[i])
+			continue
+		}
+		devices = append(devices, results[i]...)
+	}
+
+	return devices, multiErr.ErrorOrNil()
+}
@@ -0,0 +1,52 @@
+package tado
+
+import (
+	"context"
+	"time"
+)
+
+// WaitInterval is the interval at which ZoneService.WaitForTemperature polls
+// the zone state. Tado devices report temperatures every few minutes, so
+// polling more often does not return earlier.
+var WaitInterval = time.Minute
+
+// WaitForTemperature waits until the measured temperature of the zone with the
+// given ID of the provided home ID crosses target, and returns the zone state
+// at that time. target and tolerance are in the preferred unit of the client
+// (see Client.PreferredUnit).
+//
+// Whether the zone is heating up or cooling down is determined from the first
+// measurement: if it is below target, WaitForTemperature returns once the
+// temperature is at least target-tolerance; otherwise once it is at most
+// target+tolerance. If the target is not crossed within timeout, the last state
+// is returned together with an error wrapping ErrPollTimeout.
+func (s *ZoneService) WaitForTemperature(ctx context.Context, homeID HomeID, zoneID ZoneID, target, tolerance float64, timeout time.Duration) (*ZoneState, error) {
+	unit, err := s.client.PreferredUnit(ctx, homeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rising *bool
+	return PollUntil(ctx, s.client,
+		func(ctx context.Context) (*ZoneState, error) {
+			return s.GetState(ctx, homeID, zoneID)
+		},
+		func(state *ZoneState) bool {
+			t := state.SensorDataPoints.InsideTemperature
+			if t == nil {
+				return false
+			}
+
+			value := t.In(unit)
+			if rising == nil {
+				r := value < target
+				rising = &r
+			}
+
+			if *rising {
+				return value >= target-tolerance
+			}
+			return value <= target+tolerance
+		},
+		WaitInterval, timeout)
+}
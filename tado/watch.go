@@ -0,0 +1,382 @@
+package tado
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+)
+
+// DefaultWatchInterval is the default interval at which Watch polls a home.
+var DefaultWatchInterval = time.Minute
+
+// WatchEventType represents the type of a WatchEvent.
+type WatchEventType string
+
+const (
+	TemperatureChanged WatchEventType = "TEMPERATURE_CHANGED"
+	PresenceChanged    WatchEventType = "PRESENCE_CHANGED"
+	OverlaySet         WatchEventType = "OVERLAY_SET"
+	OverlayCleared     WatchEventType = "OVERLAY_CLEARED"
+	DeviceOffline      WatchEventType = "DEVICE_OFFLINE"
+	DeviceOnline       WatchEventType = "DEVICE_ONLINE"
+	WeatherChanged     WatchEventType = "WEATHER_CHANGED"
+	WatchFailed        WatchEventType = "FAILED"
+
+	OutsideTemperatureDropping WatchEventType = "OUTSIDE_TEMPERATURE_DROPPING"
+)
+
+// DefaultOutsideDropWindow is the default period over which the rate of change
+// of the outside temperature is measured, see WatchOptions.OutsideDropRate.
+var DefaultOutsideDropWindow = time.Hour
+
+// WatchOptions configures Watch. The zero value polls the zone states, home
+// state, weather and devices of a home every DefaultWatchInterval.
+type WatchOptions struct {
+	// Interval is the interval at which the home is polled. It defaults to
+	// DefaultWatchInterval.
+	Interval time.Duration
+
+	// TemperatureThreshold is the minimum change in degrees Celsius of an
+	// inside or outside temperature that is reported. It defaults to 0.1.
+	TemperatureThreshold float64
+
+	// NoWeather and NoDevices disable polling the weather and the devices
+	// of the home, saving a request per poll each.
+	NoWeather bool
+	NoDevices bool
+
+	// OutsideDropRate is the rate in degrees Celsius per hour at or above
+	// which a falling outside temperature is reported as an
+	// OutsideTemperatureDropping event, e.g. to preheat before the rooms
+	// cool down. Zero disables these events.
+	OutsideDropRate float64
+
+	// OutsideDropWindow is the period over which the rate is measured. It
+	// defaults to DefaultOutsideDropWindow. A rate is only computed once the
+	// samples span at least a quarter of the window.
+	OutsideDropWindow time.Duration
+}
+
+// WatchEvent is delivered by Watch when the state of a home changes, or when
+// polling it fails. ZoneID is zero for changes of the home itself. The other
+// fields are set depending on the Type:
+//
+//   - TemperatureChanged: Previous and Temperature, the inside temperature
+//   - PresenceChanged: Presence
+//   - OverlaySet: Overlay
+//   - OverlayCleared: none
+//   - DeviceOffline, DeviceOnline: Device
+//   - WeatherChanged: Previous, Temperature and Weather, the outside
+//     temperature and the weather
+//   - OutsideTemperatureDropping: Previous, Temperature, Weather and Rate,
+//     the outside temperature at the start of the window, the current one,
+//     the weather and the rate of the drop in degrees Celsius per hour. It is
+//     delivered once when the rate reaches WatchOptions.OutsideDropRate, and
+//     again only after the rate fell below it
+//   - WatchFailed: Err
+type WatchEvent struct {
+	Type        WatchEventType
+	HomeID      HomeID
+	ZoneID      ZoneID
+	Time        time.Time
+	Previous    *Temperature
+	Temperature *Temperature
+	Presence    Presence
+	Overlay     *Overlay
+	Device      *Device
+	Weather     *Weather
+	Rate        float64
+	Err         error
+}
+
+// watchedHome is the state of a home as last seen by Watch.
+type watchedHome struct {
+	states  map[ZoneID]*ZoneState
+	state   *State
+	weather *Weather
+	online  map[DeviceSerial]bool
+}
+
+// Watch polls the zone states, home state, weather and devices of the home
+// with the given ID at the interval of opts, and delivers a WatchEvent for
+// every change. The first poll establishes the initial state and delivers no
+// events. Polling errors are delivered as WatchFailed events, after which the
+// changes of the parts that were polled successfully are still delivered.
+//
+// The requests are sent with PriorityLow. The returned channel is closed when
+// ctx is done.
+func (c *Client) Watch(ctx context.Context, homeID HomeID, opts WatchOptions) <-chan WatchEvent {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultWatchInterval
+	}
+	if opts.TemperatureThreshold <= 0 {
+		opts.TemperatureThreshold = 0.1
+	}
+	if opts.OutsideDropWindow <= 0 {
+		opts.OutsideDropWindow = DefaultOutsideDropWindow
+	}
+
+	events := make(chan WatchEvent)
+
+	untrack := c.trackSubscription(fmt.Sprintf("watch/home/%d", homeID))
+	ctx = ContextWithPriority(ctx, PriorityLow)
+
+	go func() {
+		defer untrack()
+		defer close(events)
+
+		w := &watcher{client: c, homeID: homeID, opts: opts, events: events}
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+
+		for {
+			if !w.poll(ctx) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events
+}
+
+// watcher holds the state of a Watch.
+type watcher struct {
+	client *Client
+	homeID HomeID
+	opts   WatchOptions
+	events chan<- WatchEvent
+
+	last *watchedHome
+
+	// outside holds the samples of the outside temperature within the drop
+	// window, oldest first, and dropping whether a drop was reported.
+	outside  []outsideSample
+	dropping bool
+}
+
+// outsideSample is a sample of the outside temperature.
+type outsideSample struct {
+	at          time.Time
+	temperature Temperature
+}
+
+// poll polls the home once and delivers the changes since the last poll. It
+// returns false if ctx is done.
+func (w *watcher) poll(ctx context.Context) bool {
+	now := time.Now()
+	current := &watchedHome{states: map[ZoneID]*ZoneState{}}
+
+	errs := &MultiError{}
+	states, err := w.client.Zone.States(ctx, w.homeID)
+	if err != nil {
+		errs.Add("zones", err)
+	}
+	for zoneID, state := range states {
+		current.states[zoneID] = state
+	}
+
+	state, err := w.client.Home.GetState(ctx, w.homeID)
+	if err != nil {
+		errs.Add("state", err)
+	}
+	current.state = state
+
+	if !w.opts.NoWeather {
+		weather, err := w.client.Home.GetWeather(ctx, w.homeID)
+		if err != nil {
+			errs.Add("weather", err)
+		}
+		current.weather = weather
+	}
+
+	if !w.opts.NoDevices {
+		devices, err := w.client.Device.List(ctx, w.homeID)
+		if err != nil {
+			errs.Add("devices", err)
+		} else {
+			current.online = make(map[DeviceSerial]bool, len(devices))
+			for _, device := range devices {
+				current.online[device.SerialNo] = device.IsOnline()
+			}
+		}
+	}
+
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var events []WatchEvent
+	if err := errs.ErrorOrNil(); err != nil {
+		events = append(events, WatchEvent{Type: WatchFailed, Err: err})
+	}
+	if w.last != nil {
+		events = append(events, w.diff(w.last, current)...)
+	}
+	if e, ok := w.outsideDrop(current.weather, now); ok {
+		events = append(events, e)
+	}
+	w.last = w.merge(w.last, current)
+
+	for _, e := range events {
+		e.HomeID, e.Time = w.homeID, now
+		select {
+		case w.events <- e:
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	return true
+}
+
+// merge returns the current state, completed with the parts of the last state
+// that could not be polled, so that they are compared on the next poll.
+func (w *watcher) merge(last, current *watchedHome) *watchedHome {
+	if last == nil {
+		return current
+	}
+
+	for zoneID, state := range last.states {
+		if _, ok := current.states[zoneID]; !ok {
+			current.states[zoneID] = state
+		}
+	}
+	if current.state == nil {
+		current.state = last.state
+	}
+	if current.weather == nil {
+		current.weather = last.weather
+	}
+	if current.online == nil {
+		current.online = last.online
+	}
+
+	return current
+}
+
+// diff returns the events for the changes from last to current.
+func (w *watcher) diff(last, current *watchedHome) []WatchEvent {
+	var events []WatchEvent
+
+	for _, zoneID := range slices.Sorted(maps.Keys(current.states)) {
+		before, after := last.states[zoneID], current.states[zoneID]
+		if before == nil || after == nil {
+			continue
+		}
+
+		if e, ok := w.temperatureChange(before.SensorDataPoints.InsideTemperature, after.SensorDataPoints.InsideTemperature); ok {
+			e.Type, e.ZoneID = TemperatureChanged, zoneID
+			events = append(events, e)
+		}
+
+		switch {
+		case before.Overlay != nil && after.Overlay == nil:
+			events = append(events, WatchEvent{Type: OverlayCleared, ZoneID: zoneID})
+		case after.Overlay != nil && (before.Overlay == nil || !overlayEqual(before.Overlay, after.Overlay)):
+			events = append(events, WatchEvent{Type: OverlaySet, ZoneID: zoneID, Overlay: after.Overlay})
+		}
+	}
+
+	if last.state != nil && current.state != nil && last.state.Presence != current.state.Presence {
+		events = append(events, WatchEvent{Type: PresenceChanged, Presence: current.state.Presence})
+	}
+
+	if last.weather != nil && current.weather != nil {
+		e, changed := w.temperatureChange(&last.weather.OutsideTemperature, &current.weather.OutsideTemperature)
+		if changed || last.weather.WeatherState.Value != current.weather.WeatherState.Value {
+			e.Type, e.Weather = WeatherChanged, current.weather
+			if !changed {
+				e.Previous, e.Temperature = &last.weather.OutsideTemperature.Temperature, &current.weather.OutsideTemperature.Temperature
+			}
+			events = append(events, e)
+		}
+	}
+
+	for _, serialNo := range slices.Sorted(maps.Keys(current.online)) {
+		online := current.online[serialNo]
+		wasOnline, ok := last.online[serialNo]
+		if !ok || wasOnline == online {
+			continue
+		}
+
+		device := &Device{SerialNo: serialNo}
+		if online {
+			events = append(events, WatchEvent{Type: DeviceOnline, Device: device})
+		} else {
+			events = append(events, WatchEvent{Type: DeviceOffline, Device: device})
+		}
+	}
+
+	return events
+}
+
+// temperatureChange returns an event holding the previous and current
+// temperature, and whether they differ by at least the threshold.
+func (w *watcher) temperatureChange(before, after *TemperatureDataPoint) (WatchEvent, bool) {
+	if before == nil || after == nil || math.Abs(after.Celsius-before.Celsius) < w.opts.TemperatureThreshold {
+		return WatchEvent{}, false
+	}
+
+	return WatchEvent{Previous: &before.Temperature, Temperature: &after.Temperature}, true
+}
+
+// outsideDrop records the outside temperature of weather, and returns an
+// OutsideTemperatureDropping event if it started falling at least at the
+// configured rate.
+func (w *watcher) outsideDrop(weather *Weather, now time.Time) (WatchEvent, bool) {
+	if w.opts.OutsideDropRate <= 0 || weather == nil {
+		return WatchEvent{}, false
+	}
+
+	at := weather.OutsideTemperature.Timestamp
+	if at.IsZero() {
+		at = now
+	}
+	if n := len(w.outside); n == 0 || at.After(w.outside[n-1].at) {
+		w.outside = append(w.outside, outsideSample{at: at, temperature: weather.OutsideTemperature.Temperature})
+	}
+
+	latest := w.outside[len(w.outside)-1]
+	for len(w.outside) > 1 && latest.at.Sub(w.outside[0].at) > w.opts.OutsideDropWindow {
+		w.outside = w.outside[1:]
+	}
+
+	oldest := w.outside[0]
+	span := latest.at.Sub(oldest.at)
+	if span < w.opts.OutsideDropWindow/4 {
+		return WatchEvent{}, false
+	}
+
+	rate := (oldest.temperature.Celsius - latest.temperature.Celsius) / span.Hours()
+	if rate < w.opts.OutsideDropRate {
+		w.dropping = false
+		return WatchEvent{}, false
+	}
+	if w.dropping {
+		return WatchEvent{}, false
+	}
+	w.dropping = true
+
+	return WatchEvent{
+		Type:        OutsideTemperatureDropping,
+		Previous:    &oldest.temperature,
+		Temperature: &latest.temperature,
+		Weather:     weather,
+		Rate:        rate,
+	}, true
+}
+
+// overlayEqual reports whether the overlays a and b have the same setting and
+// termination type.
+func overlayEqual(a, b *Overlay) bool {
+	return a.Setting.matches(b.Setting) && a.Termination.Type == b.Termination.Type
+}
@@ -0,0 +1,110 @@
+package tado
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// WindInfo is the wind at a home, part of the extended weather.
+type WindInfo struct {
+	// Speed is the wind speed in km/h.
+	Speed float64 `json:"speed"`
+
+	// Direction is the direction the wind comes from, in degrees clockwise
+	// from north.
+	Direction float64   `json:"direction"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PrecipitationInfo is the precipitation at a home, part of the extended
+// weather.
+type PrecipitationInfo struct {
+	// Probability is the probability of precipitation in percent.
+	Probability float64 `json:"probability"`
+
+	// Amount is the amount of precipitation in mm.
+	Amount    float64   `json:"amount"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WindInfo returns the wind at the home and whether the weather includes it.
+func (w *Weather) WindInfo() (WindInfo, bool) {
+	if w.Wind == nil {
+		return WindInfo{}, false
+	}
+
+	return *w.Wind, true
+}
+
+// PrecipitationInfo returns the precipitation at the home and whether the
+// weather includes it.
+func (w *Weather) PrecipitationInfo() (PrecipitationInfo, bool) {
+	if w.Precipitation == nil {
+		return PrecipitationInfo{}, false
+	}
+
+	return *w.Precipitation, true
+}
+
+// weatherFields holds the names of the JSON fields of a Weather that have a
+// dedicated field.
+var weatherFields = jsonFieldNames(reflect.TypeOf(Weather{}))
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The extended
+// fields are decoded leniently: if the wind or precipitation cannot be
+// decoded, it is left nil and kept in Extended instead of failing the whole
+// weather, as are the fields without a dedicated field.
+func (w *Weather) UnmarshalJSON(data []byte) error {
+	type weather Weather
+	var decoded struct {
+		weather
+		Wind          json.RawMessage `json:"wind,omitempty"`
+		Precipitation json.RawMessage `json:"precipitation,omitempty"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*w = Weather(decoded.weather)
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	w.Extended = nil
+	for name, value := range fields {
+		if weatherFields[name] {
+			continue
+		}
+		w.extend(name, value)
+	}
+
+	if len(decoded.Wind) > 0 && string(decoded.Wind) != "null" {
+		var wind WindInfo
+		if err := json.Unmarshal(decoded.Wind, &wind); err == nil {
+			w.Wind = &wind
+		} else {
+			w.extend("wind", decoded.Wind)
+		}
+	}
+
+	if len(decoded.Precipitation) > 0 && string(decoded.Precipitation) != "null" {
+		var precipitation PrecipitationInfo
+		if err := json.Unmarshal(decoded.Precipitation, &precipitation); err == nil {
+			w.Precipitation = &precipitation
+		} else {
+			w.extend("precipitation", decoded.Precipitation)
+		}
+	}
+
+	return nil
+}
+
+// extend stores the raw value of a field in Extended.
+func (w *Weather) extend(name string, value json.RawMessage) {
+	if w.Extended == nil {
+		w.Extended = map[string]json.RawMessage{}
+	}
+	w.Extended[name] = value
+}
@@ -0,0 +1,259 @@
+package tado
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// ZoneType represents the type of a Tado zone.
+type ZoneType string
+
+// ZoneService handles communication with the zone-related methods of the Tado
+// API.
+type ZoneService service
+
+const (
+	ZoneTypeHeating         ZoneType = "HEATING"
+	ZoneTypeHotWater        ZoneType = "HOT_WATER"
+	ZoneTypeAirConditioning ZoneType = "AIR_CONDITIONING"
+)
+
+// Zone represents a Tado zone, i.e. a room or a hot water circuit.
+type Zone struct {
+	ID                  ZoneID              `json:"id"`
+	Name                string              `json:"name"`
+	Type                ZoneType            `json:"type"`
+	DateCreated         time.Time           `json:"dateCreated"`
+	DeviceTypes         []string            `json:"deviceTypes"`
+	Devices             []Device            `json:"devices"`
+	ReportAvailable     bool                `json:"reportAvailable"`
+	ShowScheduleSetup   bool                `json:"showScheduleSetup"`
+	SupportsDazzle      bool                `json:"supportsDazzle"`
+	DazzleEnabled       bool                `json:"dazzleEnabled"`
+	DazzleMode          DazzleMode          `json:"dazzleMode"`
+	OpenWindowDetection OpenWindowDetection `json:"openWindowDetection"`
+
+	// Metadata holds the fields of the zone that have no dedicated field,
+	// such as the room avatar stored by the app, as raw JSON. See
+	// DecodeMetadata.
+	Metadata map[string]json.RawMessage `json:"-"`
+}
+
+// DazzleMode represents the dazzle (display animation) settings of a zone.
+type DazzleMode struct {
+	Supported bool `json:"supported"`
+	Enabled   bool `json:"enabled"`
+}
+
+// OpenWindowDetection represents the open window detection settings of a
+// zone.
+type OpenWindowDetection struct {
+	Supported        bool `json:"supported"`
+	Enabled          bool `json:"enabled"`
+	TimeoutInSeconds int  `json:"timeoutInSeconds,omitempty"`
+}
+
+// List returns all zones of the home with the given ID.
+func (s *ZoneService) List(ctx context.Context, homeID HomeID) ([]Zone, error) {
+	req, err := s.client.newRequest("ZoneService", "List", "GET", fmt.Sprintf("homes/%d/zones", homeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []Zone
+	_, err = s.client.Do(ctx, req, &zones)
+	if err != nil {
+		return nil, err
+	}
+
+	return zones, nil
+}
+
+// All returns an iterator over all zones of the home with the given ID. The
+// zones are fetched when the iteration starts.
+func (s *ZoneService) All(ctx context.Context, homeID HomeID) iter.Seq2[Zone, error] {
+	return seq(func() ([]Zone, error) {
+		return s.List(ctx, homeID)
+	})
+}
+
+// Get returns the zone with the given ID of the provided home ID.
+func (s *ZoneService) Get(ctx context.Context, homeID HomeID, zoneID ZoneID) (*Zone, error) {
+	req, err := s.client.newRequest("ZoneService", "Get", "GET", fmt.Sprintf("homes/%d/zones/%d", homeID, zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var zone *Zone
+	_, err = s.client.Do(ctx, req, &zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+// EarlyStart represents the early start setting of a zone. When enabled, the
+// zone starts heating early so that the scheduled temperature is reached at
+// the start of a block.
+type EarlyStart struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetEarlyStart returns the early start setting of the zone with the given ID
+// of the provided home ID.
+func (s *ZoneService) GetEarlyStart(ctx context.Context, homeID HomeID, zoneID ZoneID) (*EarlyStart, error) {
+	req, err := s.client.newRequest("ZoneService", "GetEarlyStart", "GET", fmt.Sprintf("homes/%d/zones/%d/earlyStart", homeID, zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var earlyStart *EarlyStart
+	_, err = s.client.Do(ctx, req, &earlyStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return earlyStart, nil
+}
+
+// SetEarlyStart enables or disables early start for the zone with the given ID
+// of the provided home ID.
+func (s *ZoneService) SetEarlyStart(ctx context.Context, homeID HomeID, zoneID ZoneID, enabled bool, opts ...WriteOption) (*EarlyStart, error) {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "SetEarlyStart", "PUT", fmt.Sprintf("homes/%d/zones/%d/earlyStart", homeID, zoneID), &EarlyStart{Enabled: enabled}, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var earlyStart *EarlyStart
+	_, err = s.client.Do(ctx, req, &earlyStart)
+	if err != nil {
+		return nil, err
+	}
+
+	return earlyStart, nil
+}
+
+// SetDazzle enables or disables dazzle mode, the animation of the device
+// displays when the setting of a zone changes, for the zone with the given ID
+// of the provided home ID. See Zone.SupportsDazzle and Zone.DazzleMode for
+// the current settings.
+func (s *ZoneService) SetDazzle(ctx context.Context, homeID HomeID, zoneID ZoneID, enabled bool, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "SetDazzle", "PUT", fmt.Sprintf("homes/%d/zones/%d/dazzle", homeID, zoneID), &map[string]bool{"enabled": enabled}, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ZoneOrder is an entry of the order of the zones of a home, as shown in the
+// app.
+type ZoneOrder struct {
+	ID ZoneID `json:"id"`
+}
+
+// SetOrder sets the order in which the zones of the home with the given ID
+// are shown. The order must include every zone of the home.
+func (s *ZoneService) SetOrder(ctx context.Context, homeID HomeID, order []ZoneOrder, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "SetOrder", "PUT", fmt.Sprintf("homes/%d/zoneOrder", homeID), &order, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ZoneUpdate holds the settings of a zone to change with Update. Nil fields
+// are left unchanged.
+type ZoneUpdate struct {
+	Name                *string
+	OpenWindowDetection *OpenWindowDetection
+
+	// Metadata holds metadata fields to write along with the details of the
+	// zone, e.g. ones read from Zone.Metadata. Fields that Tado does not
+	// store for a zone are ignored by it.
+	Metadata map[string]any
+}
+
+// Update changes the name, metadata and/or open window detection settings of
+// the zone with the given ID of the provided home ID, and returns the updated
+// zone.
+func (s *ZoneService) Update(ctx context.Context, homeID HomeID, zoneID ZoneID, update ZoneUpdate, opts ...WriteOption) (*Zone, error) {
+	o := newWriteOptions(opts)
+
+	if update.OpenWindowDetection != nil {
+		owd := update.OpenWindowDetection
+		if err := s.SetOpenWindowDetection(ctx, homeID, zoneID, owd.Enabled, owd.TimeoutInSeconds, opts...); err != nil {
+			return nil, err
+		}
+	}
+
+	if update.Name == nil && len(update.Metadata) == 0 {
+		return s.Get(ctx, homeID, zoneID)
+	}
+
+	body := map[string]any{}
+	for name, value := range update.Metadata {
+		body[name] = value
+	}
+	if update.Name != nil {
+		body["name"] = *update.Name
+	} else {
+		zone, err := s.Get(ctx, homeID, zoneID)
+		if err != nil {
+			return nil, err
+		}
+		body["name"] = zone.Name
+	}
+
+	req, err := s.client.newRequest("ZoneService", "Update", "PUT", fmt.Sprintf("homes/%d/zones/%d/details", homeID, zoneID), &body, o.requestOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	var zone *Zone
+	_, err = s.client.Do(ctx, req, &zone)
+	if err != nil {
+		return nil, err
+	}
+
+	return zone, nil
+}
+
+// Delete deletes the zone with the given ID of the provided home ID. The
+// devices of the zone must be removed or moved to another zone first.
+func (s *ZoneService) Delete(ctx context.Context, homeID HomeID, zoneID ZoneID, opts ...WriteOption) error {
+	o := newWriteOptions(opts)
+
+	req, err := s.client.newRequest("ZoneService", "Delete", "DELETE", fmt.Sprintf("homes/%d/zones/%d", homeID, zoneID), nil, o.requestOptions...)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.Do(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package tado
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupportedSetting is returned when a setting is not supported by a
+// zone, see ZoneCapabilities.
+var ErrUnsupportedSetting = errors.New("setting not supported by zone")
+
+// TemperatureRange is the range of temperatures a zone can be set to.
+type TemperatureRange struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Step float64 `json:"step"`
+}
+
+// TemperatureRanges holds the range of temperatures a zone can be set to in
+// both Celsius and Fahrenheit.
+type TemperatureRanges struct {
+	Celsius    TemperatureRange `json:"celsius"`
+	Fahrenheit TemperatureRange `json:"fahrenheit"`
+}
+
+// ZoneCapabilities describes the settings supported by a zone. Temperatures is
+// nil for zones whose temperature cannot be set, such as hot water zones of
+// boilers that can only be switched on and off.
+//
+// The capabilities of air conditioning zones are described per mode instead,
+// see Mode.
+type ZoneCapabilities struct {
+	Type              ZoneType           `json:"type"`
+	CanSetTemperature *bool              `json:"canSetTemperature,omitempty"`
+	Temperatures      *TemperatureRanges `json:"temperatures,omitempty"`
+
+	Cool *ACModeCapabilities `json:"COOL,omitempty"`
+	Heat *ACModeCapabilities `json:"HEAT,omitempty"`
+	Dry  *ACModeCapabilities `json:"DRY,omitempty"`
+	Fan  *ACModeCapabilities `json:"FAN,omitempty"`
+	Auto *ACModeCapabilities `json:"AUTO,omitempty"`
+}
+
+// ACModeCapabilities describes the settings supported by an air conditioning
+// zone in a mode. Temperatures is nil if the temperature cannot be set in the
+// mode, and the lists are empty for settings the unit does not support.
+type ACModeCapabilities struct {
+	Temperatures    *TemperatureRanges `json:"temperatures,omitempty"`
+	FanLevel        []FanLevel         `json:"fanLevel,omitempty"`
+	VerticalSwing   []Swing            `json:"verticalSwing,omitempty"`
+	HorizontalSwing []Swing            `json:"horizontalSwing,omitempty"`
+	Light           []Light            `json:"light,omitempty"`
+}
+
+// Mode returns the capabilities of an air conditioning zone in the given
+// mode, and whether the zone supports the mode.
+func (c *ZoneCapabilities) Mode(mode ACMode) (*ACModeCapabilities, bool) {
+	var m *ACModeCapabilities
+	switch mode {
+	case ACModeCool:
+		m = c.Cool
+	case ACModeHeat:
+		m = c.Heat
+	case ACModeDry:
+		m = c.Dry
+	case ACModeFan:
+		m = c.Fan
+	case ACModeAuto:
+		m = c.Auto
+	}
+
+	return m, m != nil
+}
+
+// SupportsTemperature reports whether the temperature of the zone can be set.
+func (c *ZoneCapabilities) SupportsTemperature() bool {
+	if c.CanSetTemperature != nil && !*c.CanSetTemperature {
+		return false
+	}
+
+	return c.Temperatures != nil
+}
+
+// validateTemperature returns an error wrapping ErrUnsupportedSetting if the
+// zone cannot be set to the given temperature.
+func (c *ZoneCapabilities) validateTemperature(t Temperature) error {
+	if !c.SupportsTemperature() {
+		return fmt.Errorf("%w: %s zone has no temperature", ErrUnsupportedSetting, c.Type)
+	}
+
+	return c.Temperatures.validate(t)
+}
+
+// validate returns an error wrapping ErrUnsupportedSetting if the given
+// temperature is outside the ranges.
+func (r *TemperatureRanges) validate(t Temperature) error {
+	if t.Celsius < r.Celsius.Min || t.Celsius > r.Celsius.Max {
+		return fmt.Errorf("%w: %.1f°C is outside %.1f°C to %.1f°C", ErrUnsupportedSetting, t.Celsius, r.Celsius.Min, r.Celsius.Max)
+	}
+
+	return nil
+}
+
+// GetCapabilities returns the capabilities of the zone with the given ID of
+// the provided home ID.
+func (s *ZoneService) GetCapabilities(ctx context.Context, homeID HomeID, zoneID ZoneID) (*ZoneCapabilities, error) {
+	req, err := s.client.newRequest("ZoneService", "GetCapabilities", "GET", fmt.Sprintf("homes/%d/zones/%d/capabilities", homeID, zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var capabilities *ZoneCapabilities
+	_, err = s.client.Do(ctx, req, &capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	return capabilities, nil
+}
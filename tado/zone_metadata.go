@@ -0,0 +1,95 @@
+package tado
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// zoneFields holds the names of the JSON fields of a Zone that have a
+// dedicated field.
+var zoneFields = jsonFieldNames(reflect.TypeOf(Zone{}))
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The fields of the
+// zone without a dedicated field, such as the room avatar and other metadata
+// stored by the app, are kept in Metadata.
+func (z *Zone) UnmarshalJSON(data []byte) error {
+	type zone Zone
+	if err := json.Unmarshal(data, (*zone)(z)); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	z.Metadata = nil
+	for name, value := range fields {
+		if zoneFields[name] {
+			continue
+		}
+		if z.Metadata == nil {
+			z.Metadata = map[string]json.RawMessage{}
+		}
+		z.Metadata[name] = value
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. The fields in Metadata
+// are included, so that they survive a round trip.
+func (z Zone) MarshalJSON() ([]byte, error) {
+	type zone Zone
+	data, err := json.Marshal(zone(z))
+	if err != nil || len(z.Metadata) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range z.Metadata {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// DecodeMetadata decodes the metadata field of the zone with the given name
+// into v, and reports whether the zone has the field.
+func (z *Zone) DecodeMetadata(name string, v any) (bool, error) {
+	value, ok := z.Metadata[name]
+	if !ok {
+		return false, nil
+	}
+
+	if err := json.Unmarshal(value, v); err != nil {
+		return true, fmt.Errorf("decoding metadata %q of zone %d: %w", name, z.ID, err)
+	}
+
+	return true, nil
+}
+
+// jsonFieldNames returns the names of the JSON fields of the struct type t.
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+		case "":
+			names[field.Name] = true
+		default:
+			names[name] = true
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,164 @@
+package tado
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Power represents the power setting of a zone.
+type Power string
+
+// TerminationType represents the way an overlay terminates.
+type TerminationType string
+
+const (
+	PowerOn  Power = "ON"
+	PowerOff Power = "OFF"
+)
+
+const (
+	TerminationManual   TerminationType = "MANUAL"
+	TerminationTimer    TerminationType = "TIMER"
+	TerminationTadoMode TerminationType = "TADO_MODE"
+)
+
+// ZoneSetting represents the setting of a zone, e.g. heating at 21°C.
+// Temperature is nil when the zone is switched off. The mode, fan level,
+// swing and light are only set for air conditioning zones, see
+// CoolingSetting.
+type ZoneSetting struct {
+	Type            ZoneType     `json:"type"`
+	Power           Power        `json:"power"`
+	Temperature     *Temperature `json:"temperature,omitempty"`
+	Mode            ACMode       `json:"mode,omitempty"`
+	FanLevel        FanLevel     `json:"fanLevel,omitempty"`
+	VerticalSwing   Swing        `json:"verticalSwing,omitempty"`
+	HorizontalSwing Swing        `json:"horizontalSwing,omitempty"`
+	Light           Light        `json:"light,omitempty"`
+}
+
+// Termination represents the termination condition of an overlay.
+type Termination struct {
+	Type                   TerminationType `json:"type"`
+	TypeSkillBasedApp      string          `json:"typeSkillBasedApp,omitempty"`
+	DurationInSeconds      int             `json:"durationInSeconds,omitempty"`
+	Expiry                 *time.Time      `json:"expiry,omitempty"`
+	RemainingTimeInSeconds int             `json:"remainingTimeInSeconds,omitempty"`
+	ProjectedExpiry        *time.Time      `json:"projectedExpiry,omitempty"`
+}
+
+// Overlay represents a manual setting overriding the schedule of a zone.
+type Overlay struct {
+	Type        string      `json:"type,omitempty"`
+	Setting     ZoneSetting `json:"setting"`
+	Termination Termination `json:"termination"`
+}
+
+// OpenWindow represents an open window detected in a zone.
+type OpenWindow struct {
+	DetectedTime           time.Time `json:"detectedTime"`
+	DurationInSeconds      int       `json:"durationInSeconds"`
+	Expiry                 time.Time `json:"expiry"`
+	RemainingTimeInSeconds int       `json:"remainingTimeInSeconds"`
+}
+
+// PercentageDataPoint represents a percentage measured at a point in time.
+type PercentageDataPoint struct {
+	Type       string    `json:"type"`
+	Percentage float64   `json:"percentage"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// TemperatureDataPoint represents a temperature measured at a point in time.
+type TemperatureDataPoint struct {
+	Temperature
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Precision Temperature `json:"precision"`
+}
+
+// MarshalJSON implements the json.Marshaler interface. It is needed because
+// the MarshalJSON method of the embedded Temperature would otherwise be
+// promoted and leave out the other fields.
+func (p TemperatureDataPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Celsius    float64     `json:"celsius"`
+		Fahrenheit float64     `json:"fahrenheit"`
+		Type       string      `json:"type"`
+		Timestamp  time.Time   `json:"timestamp"`
+		Precision  Temperature `json:"precision"`
+	}{p.Celsius, p.Fahrenheit, p.Type, p.Timestamp, p.Precision})
+}
+
+// ZoneState represents the current state of a Tado zone.
+type ZoneState struct {
+	TadoMode            Presence    `json:"tadoMode"`
+	GeolocationOverride bool        `json:"geolocationOverride"`
+	Setting             ZoneSetting `json:"setting"`
+	OverlayType         string      `json:"overlayType,omitempty"`
+	Overlay             *Overlay    `json:"overlay,omitempty"`
+	OpenWindow          *OpenWindow `json:"openWindow,omitempty"`
+	OpenWindowDetected  bool        `json:"openWindowDetected,omitempty"`
+	NextScheduleChange  *struct {
+		Start   time.Time   `json:"start"`
+		Setting ZoneSetting `json:"setting"`
+	} `json:"nextScheduleChange,omitempty"`
+	NextTimeBlock *struct {
+		Start time.Time `json:"start"`
+	} `json:"nextTimeBlock,omitempty"`
+	Link struct {
+		State string `json:"state"`
+	} `json:"link"`
+	RunningOfflineSchedule bool `json:"runningOfflineSchedule"`
+	ActivityDataPoints     struct {
+		HeatingPower *PercentageDataPoint `json:"heatingPower,omitempty"`
+	} `json:"activityDataPoints"`
+	SensorDataPoints struct {
+		InsideTemperature *TemperatureDataPoint `json:"insideTemperature,omitempty"`
+		Humidity          *PercentageDataPoint  `json:"humidity,omitempty"`
+	} `json:"sensorDataPoints"`
+}
+
+// GetState returns the state of the zone with the given ID of the provided
+// home ID.
+func (s *ZoneService) GetState(ctx context.Context, homeID HomeID, zoneID ZoneID) (*ZoneState, error) {
+	req, err := s.client.newRequest("ZoneService", "GetState", "GET", fmt.Sprintf("homes/%d/zones/%d/state", homeID, zoneID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var state *ZoneState
+	_, err = s.client.Do(ctx, req, &state)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// errNoZoneState is recorded for the zones that are missing from the result
+// of States.
+var errNoZoneState = errors.New("no zone state")
+
+// States returns the states of all zones of the home with the given ID, keyed
+// by zone ID, using a single request. Prefer it over calling GetState for
+// every zone when polling a home, to stay within the rate limit.
+func (s *ZoneService) States(ctx context.Context, homeID HomeID) (map[ZoneID]*ZoneState, error) {
+	req, err := s.client.newRequest("ZoneService", "States", "GET", fmt.Sprintf("homes/%d/zoneStates", homeID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var states struct {
+		ZoneStates map[ZoneID]*ZoneState `json:"zoneStates"`
+	}
+	_, err = s.client.Do(ctx, req, &states)
+	if err != nil {
+		return nil, err
+	}
+
+	return states.ZoneStates, nil
+}
@@ -0,0 +1,20 @@
+module github.com/idriesalbender/go-tado/tadotel
+
+go 1.23.5
+
+require (
+	github.com/idriesalbender/go-tado v0.0.0
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/metric v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	golang.org/x/oauth2 v0.25.0 // indirect
+	golang.org/x/time v0.9.0 // indirect
+)
+
+replace github.com/idriesalbender/go-tado => ../
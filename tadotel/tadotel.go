@@ -0,0 +1,185 @@
+// Package tadotel instruments a tado.Client with OpenTelemetry. Every API
+// request is traced as a client span, labeled with the operation and the home,
+// zone, room and device it is about, and counted and timed in metrics.
+//
+// Example usage:
+//
+//	client := tado.NewClient(tadotel.WithTelemetry(tracerProvider, meterProvider))
+//
+// The package is a separate module, so that clients without telemetry do not
+// depend on OpenTelemetry.
+package tadotel
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// ScopeName is the instrumentation scope name of the tracer and meter.
+const ScopeName = "github.com/idriesalbender/go-tado/tadotel"
+
+// Attribute keys of the Operation of a request. The home, zone, room and
+// device are only set on spans, to keep the cardinality of the metrics low.
+const (
+	ServiceKey      = attribute.Key("tado.service")
+	OperationKey    = attribute.Key("tado.operation")
+	HomeIDKey       = attribute.Key("tado.home_id")
+	ZoneIDKey       = attribute.Key("tado.zone_id")
+	RoomIDKey       = attribute.Key("tado.room_id")
+	DeviceSerialKey = attribute.Key("tado.device_serial")
+)
+
+// WithTelemetry instruments the API requests of the client with the given
+// providers. A nil provider defaults to the global one of the otel package.
+//
+// Every attempt of a request is a span named after its operation, e.g.
+// "ZoneService.SetOverlay", and is recorded in the metrics:
+//
+//   - tado.client.requests: the number of requests
+//   - tado.client.errors: the number of requests that failed, i.e. that
+//     received no response or an error status
+//   - tado.client.request.duration: the duration of the requests in seconds
+//
+// The metrics are labeled with the service and operation, the method, the
+// status code and the error type, if any. Token requests are not
+// instrumented.
+func WithTelemetry(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) tado.ClientOption {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+
+	return tado.WithTransportMiddleware(func(base http.RoundTripper) http.RoundTripper {
+		return newTransport(base, tracerProvider, meterProvider)
+	})
+}
+
+// transport is a http.RoundTripper instrumenting the requests sent by base.
+type transport struct {
+	base     http.RoundTripper
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// newTransport returns a transport instrumenting base with the given
+// providers. Errors creating the instruments are passed to otel.Handle, which
+// leaves the instruments no-ops.
+func newTransport(base http.RoundTripper, tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) *transport {
+	meter := meterProvider.Meter(ScopeName)
+	t := &transport{
+		base:   base,
+		tracer: tracerProvider.Tracer(ScopeName),
+	}
+
+	var err error
+	if t.requests, err = meter.Int64Counter("tado.client.requests",
+		metric.WithDescription("Number of requests sent to the Tado API."),
+		metric.WithUnit("{request}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if t.errors, err = meter.Int64Counter("tado.client.errors",
+		metric.WithDescription("Number of requests to the Tado API that failed."),
+		metric.WithUnit("{request}"),
+	); err != nil {
+		otel.Handle(err)
+	}
+	if t.duration, err = meter.Float64Histogram("tado.client.request.duration",
+		metric.WithDescription("Duration of requests to the Tado API."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10),
+	); err != nil {
+		otel.Handle(err)
+	}
+
+	return t
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, _ := tado.OperationFromContext(req.Context())
+
+	labels := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(req.Method)}
+	if op.Service != "" {
+		labels = append(labels, ServiceKey.String(op.Service), OperationKey.String(op.Name))
+	}
+
+	attrs := append(labels[:len(labels):len(labels)],
+		semconv.URLFull(req.URL.Redacted()),
+		semconv.ServerAddress(req.URL.Hostname()),
+	)
+	if op.HomeID != 0 {
+		attrs = append(attrs, HomeIDKey.Int(int(op.HomeID)))
+	}
+	if op.ZoneID != 0 {
+		attrs = append(attrs, ZoneIDKey.Int(int(op.ZoneID)))
+	}
+	if op.RoomID != 0 {
+		attrs = append(attrs, RoomIDKey.Int(int(op.RoomID)))
+	}
+	if op.DeviceSerial != "" {
+		attrs = append(attrs, DeviceSerialKey.String(string(op.DeviceSerial)))
+	}
+
+	ctx, span := t.tracer.Start(req.Context(), spanName(req, op),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+
+	start := time.Now()
+	res, err := t.base.RoundTrip(req.WithContext(ctx))
+	elapsed := time.Since(start)
+
+	switch {
+	case err != nil:
+		errorType := semconv.ErrorTypeKey.String(fmt.Sprintf("%T", err))
+		labels = append(labels, errorType)
+		span.SetAttributes(errorType)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case res.StatusCode >= http.StatusBadRequest:
+		status := semconv.HTTPResponseStatusCode(res.StatusCode)
+		errorType := semconv.ErrorTypeKey.String(strconv.Itoa(res.StatusCode))
+		labels = append(labels, status, errorType)
+		span.SetAttributes(status, errorType)
+		span.SetStatus(codes.Error, http.StatusText(res.StatusCode))
+	default:
+		status := semconv.HTTPResponseStatusCode(res.StatusCode)
+		labels = append(labels, status)
+		span.SetAttributes(status)
+	}
+
+	set := metric.WithAttributeSet(attribute.NewSet(labels...))
+	t.requests.Add(ctx, 1, set)
+	if err != nil || res.StatusCode >= http.StatusBadRequest {
+		t.errors.Add(ctx, 1, set)
+	}
+	t.duration.Record(ctx, elapsed.Seconds(), set)
+
+	return res, err
+}
+
+// spanName returns the name of the span of a request for the given
+// operation, or its method if it has none.
+func spanName(req *http.Request, op tado.Operation) string {
+	if op.Service == "" {
+		return req.Method
+	}
+
+	return op.Service + "." + op.Name
+}
@@ -0,0 +1,105 @@
+package tadotest
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+	"golang.org/x/oauth2"
+)
+
+// tokens holds the tokens currently issued by a Server.
+type tokens struct {
+	issued  int
+	access  string
+	refresh string
+	expiry  time.Time
+}
+
+// Authenticator returns a tado.Authenticator that obtains a token from the
+// server without user interaction. The token is refreshed by the server when
+// it expires, see Server.TokenLifetime.
+func (s *Server) Authenticator() tado.Authenticator {
+	return authenticator{s}
+}
+
+// authenticator is the tado.Authenticator of a Server.
+type authenticator struct {
+	s *Server
+}
+
+// TokenSource implements the tado.Authenticator interface.
+func (a authenticator) TokenSource(ctx context.Context) (oauth2.TokenSource, error) {
+	config := &oauth2.Config{
+		ClientID: "tadotest",
+		Endpoint: oauth2.Endpoint{
+			TokenURL:  a.s.srv.URL + "/oauth2/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+
+	return config.TokenSource(ctx, a.s.issueToken()), nil
+}
+
+// issueToken issues a new token, invalidating the previous one.
+func (s *Server) issueToken() *oauth2.Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens.issued++
+	s.tokens.access = fmt.Sprintf("access-%d", s.tokens.issued)
+	s.tokens.refresh = fmt.Sprintf("refresh-%d", s.tokens.issued)
+	s.tokens.expiry = time.Now().Add(s.TokenLifetime)
+
+	return &oauth2.Token{
+		AccessToken:  s.tokens.access,
+		TokenType:    "Bearer",
+		RefreshToken: s.tokens.refresh,
+		Expiry:       s.tokens.expiry,
+	}
+}
+
+// expireToken expires the current access token.
+func (s *Server) expireToken() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens.expiry = time.Now()
+}
+
+// authorized reports whether the request carries the current, unexpired
+// access token.
+func (s *Server) authorized(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return bearerToken(r) == s.tokens.access && s.tokens.access != "" && time.Now().Before(s.tokens.expiry)
+}
+
+// serveToken serves the token endpoint, which refreshes tokens.
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	s.mu.Lock()
+	valid := r.PostForm.Get("grant_type") == "refresh_token" && r.PostForm.Get("refresh_token") == s.tokens.refresh
+	s.mu.Unlock()
+
+	if !valid {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	token := s.issueToken()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token":  token.AccessToken,
+		"token_type":    token.TokenType,
+		"refresh_token": token.RefreshToken,
+		"expires_in":    int(math.Ceil(time.Until(token.Expiry).Seconds())), // zero would mean no expiry
+	})
+}
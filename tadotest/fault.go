@@ -0,0 +1,103 @@
+package tadotest
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Fault is a failure injected into the response to a request, see
+// Server.Schedule.
+type Fault struct {
+	status      int
+	retryAfter  time.Duration
+	delay       time.Duration
+	expireToken bool
+}
+
+// RateLimited returns a Fault that answers with 429 Too Many Requests and a
+// Retry-After header of the given duration, rounded up to whole seconds. The
+// header is omitted if retryAfter is zero.
+func RateLimited(retryAfter time.Duration) Fault {
+	return Fault{status: http.StatusTooManyRequests, retryAfter: retryAfter}
+}
+
+// ServerError returns a Fault that answers with the given 5xx status code,
+// e.g. http.StatusServiceUnavailable.
+func ServerError(status int) Fault {
+	return Fault{status: status}
+}
+
+// Slow returns a Fault that delays the response by the given duration, after
+// which the request is served normally.
+func Slow(delay time.Duration) Fault {
+	return Fault{delay: delay}
+}
+
+// ExpiredToken returns a Fault that expires the current access token on the
+// server, as if it was revoked, and answers with 401 Unauthorized. Later
+// requests with the same token are rejected as well. A tado.Client only
+// refreshes its token when it expires locally, so the client keeps failing
+// until it is recreated; to test token refreshes instead, set
+// Server.TokenLifetime to a short duration.
+func ExpiredToken() Fault {
+	return Fault{status: http.StatusUnauthorized, expireToken: true}
+}
+
+// write writes the response of the fault.
+func (f Fault) write(w http.ResponseWriter) {
+	switch f.status {
+	case http.StatusTooManyRequests:
+		if f.retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(f.retryAfter.Seconds()))))
+		}
+		writeError(w, f.status, "tooManyRequests", "rate limit exceeded")
+	case http.StatusUnauthorized:
+		writeError(w, f.status, "unauthorized", "access token expired")
+	default:
+		writeError(w, f.status, "serverError", http.StatusText(f.status))
+	}
+}
+
+// scheduledFault is a Fault scheduled for a range of requests.
+type scheduledFault struct {
+	from, to int
+	fault    Fault
+}
+
+// Schedule injects the fault into count consecutive API requests, starting
+// with the at-th request the server receives (counting from 1). Faults
+// scheduled for the same request are combined, e.g. Slow and ServerError.
+//
+// Example usage, simulating a burst of 503s on the second to fourth request:
+//
+//	srv.Schedule(2, 3, tadotest.ServerError(http.StatusServiceUnavailable))
+func (s *Server) Schedule(at, count int, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, scheduledFault{from: at, to: at + count - 1, fault: fault})
+}
+
+// FailNext injects the fault into the next count API requests.
+func (s *Server) FailNext(count int, fault Fault) {
+	s.mu.Lock()
+	at := s.requests + 1
+	s.mu.Unlock()
+
+	s.Schedule(at, count, fault)
+}
+
+// faultsOf returns the faults scheduled for the n-th request. s.mu must be
+// held.
+func (s *Server) faultsOf(n int) []Fault {
+	var faults []Fault
+	for _, f := range s.faults {
+		if f.from <= n && n <= f.to {
+			faults = append(faults, f.fault)
+		}
+	}
+
+	return faults
+}
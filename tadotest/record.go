@@ -0,0 +1,66 @@
+package tadotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Request is a request received by a Server, see Server.Requests.
+type Request struct {
+	Method string
+
+	// Path is the path of the request relative to the API root, e.g.
+	// "/homes/1/zones".
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// DecodeBody decodes the JSON body of the request into v.
+func (r Request) DecodeBody(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// Requests returns the API requests received so far, in order, including the
+// ones answered with a fault. Token requests are not recorded.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.recorded...)
+}
+
+// RequestsTo returns the API requests received so far with the given method
+// and path relative to the API root.
+func (s *Server) RequestsTo(method, path string) []Request {
+	var requests []Request
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			requests = append(requests, r)
+		}
+	}
+
+	return requests
+}
+
+// newRequest records r, restoring its body so that it can still be read by
+// the handler.
+func newRequest(r *http.Request) (Request, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return Request{}, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}, nil
+}
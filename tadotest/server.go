@@ -0,0 +1,237 @@
+// Package tadotest provides a fake Tado API server for testing applications
+// built on the tado package without hitting the real API.
+//
+// The server can inject faults, such as rate limiting, server errors, slow
+// responses and expired tokens, into the responses to given requests, so that
+// retry, backoff and timeout configurations, and the handling of rejected
+// credentials, can be tested deterministically:
+//
+//	srv := tadotest.NewServer()
+//	defer srv.Close()
+//
+//	srv.Handle("GET /me", tadotest.JSON(&tado.User{Name: "Test"}))
+//	srv.Schedule(1, 2, tadotest.RateLimited(time.Second))
+//
+//	client := srv.Client(tado.WithRetry(2, nil))
+//	me, err := client.User.Get(ctx) // succeeds on the third attempt
+//
+// The server can also be seeded with homes, whose zones, devices and state it
+// serves. Overlays and presence locks written to a seeded home change its
+// state, and all requests are recorded, so that the effects of an application
+// can be asserted:
+//
+//	srv.AddHome(tadotest.Home{
+//		Home:  tado.Home{ID: 1, Name: "Test"},
+//		Zones: []tadotest.Zone{{Zone: tado.Zone{ID: 1, Name: "Living", Type: tado.ZoneTypeHeating}}},
+//	})
+//
+//	_, err := client.Zone.SetTemperature(ctx, 1, 1, 21, tado.ManualTermination())
+//	state := srv.ZoneState(1, 1) // state.Overlay is set
+//	puts := srv.RequestsTo("PUT", "/homes/1/zones/1/overlay")
+package tadotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// apiPrefix is the path of the API on the server.
+const apiPrefix = "/api/v2"
+
+// Paths of the other Tado services, relative to the API root. Requests to them
+// are served, recorded and faulted like requests to the API, and handlers for
+// them are registered using Handle with the path as prefix, e.g.
+// "GET /minder/homes/{homeID}/incidents".
+const (
+	HopsPath          = "/hops/"
+	MinderPath        = "/minder/"
+	EnergyIQPath      = "/energy-insights/"
+	EnergySavingsPath = "/energy-bob/"
+	AcmePath          = "/acme/"
+)
+
+// DefaultTokenLifetime is the default lifetime of the tokens issued by a
+// Server.
+const DefaultTokenLifetime = 10 * time.Minute
+
+// Server is a fake Tado API server. It must be created with NewServer and
+// closed with Close.
+type Server struct {
+	// URL is the base URL of the API, for use with tado.WithBaseURL. See
+	// Options for the URLs of the other services.
+	URL string
+
+	// TokenLifetime is the lifetime of the tokens issued by the server. It
+	// defaults to DefaultTokenLifetime.
+	TokenLifetime time.Duration
+
+	srv   *httptest.Server
+	mux   *http.ServeMux
+	state *http.ServeMux
+
+	mu        sync.Mutex
+	requests  int
+	recorded  []Request
+	faults    []scheduledFault
+	tokens    tokens
+	homes     map[tado.HomeID]*home
+	homeOrder []tado.HomeID
+}
+
+// NewServer starts and returns a new Server. Requests to paths that have no
+// handler and are not served from the homes seeded using AddHome are answered
+// with 404 Not Found.
+func NewServer() *Server {
+	s := &Server{
+		TokenLifetime: DefaultTokenLifetime,
+		mux:           http.NewServeMux(),
+		state:         http.NewServeMux(),
+	}
+	s.registerState()
+
+	root := http.NewServeMux()
+	root.HandleFunc("POST /oauth2/token", s.serveToken)
+	root.Handle(apiPrefix+"/", http.StripPrefix(apiPrefix, http.HandlerFunc(s.serveAPI)))
+
+	s.srv = httptest.NewServer(root)
+	s.URL = s.srv.URL + apiPrefix + "/"
+
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Handle registers the handler for the given pattern, relative to the API
+// root, e.g. "GET /homes/{homeID}/zones", or "GET /hops/homes/{homeID}/rooms"
+// for the other services. See http.ServeMux for the syntax.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// Options returns the options pointing a tado.Client at the server: the base
+// URL of the API, the URLs of the other services and Authenticator.
+func (s *Server) Options() []tado.ClientOption {
+	return []tado.ClientOption{
+		tado.WithBaseURL(s.URL),
+		tado.WithHopsURL(s.serviceURL(HopsPath)),
+		tado.WithMinderURL(s.serviceURL(MinderPath)),
+		tado.WithEnergyIQURL(s.serviceURL(EnergyIQPath)),
+		tado.WithEnergySavingsURL(s.serviceURL(EnergySavingsPath)),
+		tado.WithAcmeURL(s.serviceURL(AcmePath)),
+		tado.WithAuthenticator(s.Authenticator()),
+	}
+}
+
+// Client returns a tado.Client that talks to the server, configured with
+// Options. The given options are applied after those.
+func (s *Server) Client(opts ...tado.ClientOption) *tado.Client {
+	return tado.NewClient(append(s.Options(), opts...)...)
+}
+
+// serviceURL returns the URL of the service with the given path.
+func (s *Server) serviceURL(path string) string {
+	return s.URL + strings.TrimPrefix(path, "/")
+}
+
+// RequestCount returns the number of API requests received so far, including
+// the ones answered with a fault. Token requests are not counted.
+func (s *Server) RequestCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests
+}
+
+// serveAPI serves a request to the API, injecting the faults scheduled for it.
+// Handlers registered using Handle take precedence over the seeded state.
+func (s *Server) serveAPI(w http.ResponseWriter, r *http.Request) {
+	request, err := newRequest(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "badRequest", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	s.requests++
+	s.recorded = append(s.recorded, request)
+	faults := s.faultsOf(s.requests)
+	s.mu.Unlock()
+
+	for _, f := range faults {
+		if f.delay > 0 {
+			select {
+			case <-time.After(f.delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}
+
+	for _, f := range faults {
+		if f.expireToken {
+			s.expireToken()
+		}
+		if f.status != 0 {
+			f.write(w)
+			return
+		}
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "unauthorized", "invalid or expired access token")
+		return
+	}
+
+	if _, pattern := s.mux.Handler(r); pattern != "" {
+		s.mux.ServeHTTP(w, r)
+		return
+	}
+
+	if _, pattern := s.state.Handler(r); pattern != "" {
+		s.state.ServeHTTP(w, r)
+		return
+	}
+
+	writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf("no handler for %s %s", r.Method, r.URL.Path))
+}
+
+// JSON returns a handler that responds with v encoded as JSON.
+func JSON(v any) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, v)
+	})
+}
+
+// writeJSON writes v encoded as JSON with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error response in the format of the Tado API.
+func writeError(w http.ResponseWriter, status int, code, title string) {
+	writeJSON(w, status, map[string][]tado.APIError{
+		"errors": {{Code: code, Title: title}},
+	})
+}
+
+// bearerToken returns the bearer token of the request, if any.
+func bearerToken(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+
+	return token
+}
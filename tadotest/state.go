@@ -0,0 +1,309 @@
+package tadotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/idriesalbender/go-tado/tado"
+)
+
+// Home is a home seeded into a Server using AddHome.
+type Home struct {
+	tado.Home
+
+	// State is the initial state of the home, e.g. its presence.
+	State tado.State
+
+	// Weather is the weather at the home.
+	Weather tado.Weather
+
+	// Zones are the zones of the home.
+	Zones []Zone
+
+	// Devices are the devices of the home that do not belong to a zone, such
+	// as bridges. The devices of the zones are taken from Zone.Devices.
+	Devices []tado.Device
+}
+
+// Zone is a zone of a seeded Home.
+type Zone struct {
+	tado.Zone
+
+	// State is the initial state of the zone. Its Setting is the setting of
+	// the schedule, which the zone returns to when its overlay is removed.
+	State tado.ZoneState
+}
+
+// home is the state of a seeded home.
+type home struct {
+	Home
+	zones map[tado.ZoneID]*zone
+}
+
+// zone is the state of a zone of a seeded home.
+type zone struct {
+	Zone
+	schedule tado.ZoneSetting
+}
+
+// AddHome seeds the server with the given home, replacing any home with the
+// same ID. The server then serves the home, its state, weather, zones, zone
+// states and devices, and applies overlays and presence locks written to it.
+// Handlers registered using Handle take precedence over the seeded state.
+func (s *Server) AddHome(h Home) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seeded := &home{Home: h, zones: map[tado.ZoneID]*zone{}}
+	for _, z := range h.Zones {
+		seeded.zones[z.ID] = &zone{Zone: z, schedule: z.State.Setting}
+	}
+	if seeded.ZonesCount == 0 {
+		seeded.ZonesCount = len(h.Zones)
+	}
+
+	if s.homes == nil {
+		s.homes = map[tado.HomeID]*home{}
+	}
+	s.homes[h.ID] = seeded
+	if !slices.Contains(s.homeOrder, h.ID) {
+		s.homeOrder = append(s.homeOrder, h.ID)
+	}
+}
+
+// HomeState returns the current state of the seeded home with the given ID.
+func (s *Server) HomeState(homeID tado.HomeID) tado.State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if h := s.homes[homeID]; h != nil {
+		return h.State
+	}
+
+	return tado.State{}
+}
+
+// SetPresence changes the presence of the seeded home with the given ID, as
+// geofencing would.
+func (s *Server) SetPresence(homeID tado.HomeID, presence tado.Presence) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if h := s.homes[homeID]; h != nil {
+		h.State.Presence = presence
+	}
+}
+
+// ZoneState returns the current state of the zone with the given ID of the
+// seeded home with the provided ID.
+func (s *Server) ZoneState(homeID tado.HomeID, zoneID tado.ZoneID) tado.ZoneState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if z := s.zone(homeID, zoneID); z != nil {
+		return z.State
+	}
+
+	return tado.ZoneState{}
+}
+
+// SetZoneState replaces the state of the zone with the given ID of the seeded
+// home with the provided ID, e.g. to simulate a change of its inside
+// temperature.
+func (s *Server) SetZoneState(homeID tado.HomeID, zoneID tado.ZoneID, state tado.ZoneState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if z := s.zone(homeID, zoneID); z != nil {
+		z.State = state
+	}
+}
+
+// zone returns the zone with the given ID of the seeded home with the
+// provided ID, or nil. The caller must hold s.mu.
+func (s *Server) zone(homeID tado.HomeID, zoneID tado.ZoneID) *zone {
+	h := s.homes[homeID]
+	if h == nil {
+		return nil
+	}
+
+	return h.zones[zoneID]
+}
+
+// registerState registers the handlers serving the seeded homes.
+func (s *Server) registerState() {
+	s.state.HandleFunc("GET /me", s.serveMe)
+	s.state.HandleFunc("GET /homes/{homeID}", s.withHome(func(h *home, r *http.Request) (any, error) {
+		return h.Home.Home, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/state", s.withHome(func(h *home, r *http.Request) (any, error) {
+		return h.State, nil
+	}))
+	s.state.HandleFunc("PUT /homes/{homeID}/presenceLock", s.withHome(func(h *home, r *http.Request) (any, error) {
+		var body struct {
+			HomePresence tado.Presence `json:"homePresence"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		h.State.Presence, h.State.PresenceLocked = body.HomePresence, true
+
+		return nil, nil
+	}))
+	s.state.HandleFunc("DELETE /homes/{homeID}/presenceLock", s.withHome(func(h *home, r *http.Request) (any, error) {
+		h.State.PresenceLocked = false
+
+		return nil, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/weather", s.withHome(func(h *home, r *http.Request) (any, error) {
+		return h.Weather, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/zones", s.withHome(func(h *home, r *http.Request) (any, error) {
+		zones := []tado.Zone{}
+		for _, z := range h.Zones {
+			zones = append(zones, h.zones[z.ID].Zone.Zone)
+		}
+
+		return zones, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/zoneStates", s.withHome(func(h *home, r *http.Request) (any, error) {
+		states := map[tado.ZoneID]tado.ZoneState{}
+		for id, z := range h.zones {
+			states[id] = z.State
+		}
+
+		return map[string]any{"zoneStates": states}, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/zones/{zoneID}", s.withZone(func(z *zone, r *http.Request) (any, error) {
+		return z.Zone.Zone, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/zones/{zoneID}/state", s.withZone(func(z *zone, r *http.Request) (any, error) {
+		return z.State, nil
+	}))
+	s.state.HandleFunc("PUT /homes/{homeID}/zones/{zoneID}/overlay", s.withZone(func(z *zone, r *http.Request) (any, error) {
+		var overlay tado.Overlay
+		if err := json.NewDecoder(r.Body).Decode(&overlay); err != nil {
+			return nil, err
+		}
+		if overlay.Type == "" {
+			overlay.Type = "MANUAL"
+		}
+		z.State.Overlay, z.State.OverlayType, z.State.Setting = &overlay, overlay.Type, overlay.Setting
+
+		return overlay, nil
+	}))
+	s.state.HandleFunc("DELETE /homes/{homeID}/zones/{zoneID}/overlay", s.withZone(func(z *zone, r *http.Request) (any, error) {
+		z.State.Overlay, z.State.OverlayType, z.State.Setting = nil, "", z.schedule
+
+		return nil, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/devices", s.withHome(func(h *home, r *http.Request) (any, error) {
+		devices := append([]tado.Device{}, h.Devices...)
+		for _, z := range h.Zones {
+			devices = append(devices, h.zones[z.ID].Devices...)
+		}
+
+		return devices, nil
+	}))
+	s.state.HandleFunc("GET /homes/{homeID}/deviceList", s.withHome(func(h *home, r *http.Request) (any, error) {
+		var list struct {
+			Entries []tado.DeviceListEntry `json:"entries"`
+		}
+		list.Entries = []tado.DeviceListEntry{}
+		for _, d := range h.Devices {
+			list.Entries = append(list.Entries, tado.DeviceListEntry{Type: d.DeviceType, Device: d})
+		}
+		for _, z := range h.Zones {
+			for _, d := range h.zones[z.ID].Devices {
+				entry := tado.DeviceListEntry{Type: d.DeviceType, Device: d}
+				entry.Zone = &struct {
+					Discriminator tado.ZoneID `json:"discriminator"`
+					Duties        []tado.Duty `json:"duties"`
+				}{Discriminator: z.ID, Duties: d.Duties}
+				list.Entries = append(list.Entries, entry)
+			}
+		}
+
+		return list, nil
+	}))
+}
+
+// serveMe serves the user owning the seeded homes.
+func (s *Server) serveMe(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	me := tado.User{Name: "Test User", Email: "test@example.com", Username: "test@example.com", ID: "test", Homes: []tado.BareHome{}}
+	for _, id := range s.homeOrder {
+		me.Homes = append(me.Homes, tado.BareHome{ID: id, Name: s.homes[id].Name})
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, me)
+}
+
+// withHome returns a handler calling fn with the seeded home of the request
+// while holding s.mu. A nil result is answered with 204 No Content.
+func (s *Server) withHome(fn func(*home, *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.Atoi(r.PathValue("homeID"))
+		if err != nil {
+			writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf("home %s not found", r.PathValue("homeID")))
+			return
+		}
+
+		s.mu.Lock()
+		h := s.homes[tado.HomeID(id)]
+		if h == nil {
+			s.mu.Unlock()
+			writeError(w, http.StatusNotFound, "notFound", fmt.Sprintf("home %d not found", id))
+			return
+		}
+		v, err := fn(h, r)
+		s.mu.Unlock()
+
+		writeResult(w, v, err)
+	}
+}
+
+// withZone returns a handler calling fn with the zone of the seeded home of
+// the request while holding s.mu.
+func (s *Server) withZone(fn func(*zone, *http.Request) (any, error)) http.HandlerFunc {
+	return s.withHome(func(h *home, r *http.Request) (any, error) {
+		id, _ := strconv.Atoi(r.PathValue("zoneID"))
+		z := h.zones[tado.ZoneID(id)]
+		if z == nil {
+			return nil, errZoneNotFound(r.PathValue("zoneID"))
+		}
+
+		return fn(z, r)
+	})
+}
+
+// errZoneNotFound is returned by the handlers of a zone that does not exist.
+type errZoneNotFound string
+
+func (e errZoneNotFound) Error() string {
+	return fmt.Sprintf("zone %s not found", string(e))
+}
+
+// writeResult writes the result of a handler of the seeded state.
+func writeResult(w http.ResponseWriter, v any, err error) {
+	switch err.(type) {
+	case nil:
+	case errZoneNotFound:
+		writeError(w, http.StatusNotFound, "notFound", err.Error())
+		return
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "invalidRequest", err.Error())
+		return
+	}
+
+	if v == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, v)
+}